@@ -8,6 +8,16 @@ output "dns_domain_name" {
   value       = virakcloud_dns_domain.example_com.domain
 }
 
+output "dns_nameservers" {
+  description = "Nameservers to configure at the domain's registrar"
+  value       = virakcloud_dns_domain.example_com.nameservers
+}
+
+output "dns_delegation_status" {
+  description = "Whether the domain is delegated to the Virak DNS nameservers"
+  value       = virakcloud_dns_domain.example_com.delegation_status
+}
+
 output "dns_records" {
   description = "List of all DNS records created"
   value = [
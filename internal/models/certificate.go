@@ -0,0 +1,12 @@
+package models
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type CertificateInfoModel struct {
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+	Subject           types.String `tfsdk:"subject"`
+	Issuer            types.String `tfsdk:"issuer"`
+	DNSNames          types.List   `tfsdk:"dns_names"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	NotAfter          types.String `tfsdk:"not_after"`
+}
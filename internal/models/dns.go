@@ -3,28 +3,64 @@ package models
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type DomainResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Domain  types.String `tfsdk:"domain"`
-	Status  types.String `tfsdk:"status"`
-	DNSInfo types.String `tfsdk:"dns_info"`
+	ID                   types.String `tfsdk:"id"`
+	Domain               types.String `tfsdk:"domain"`
+	Status               types.String `tfsdk:"status"`
+	DNSInfo              types.String `tfsdk:"dns_info"`
+	Nameservers          types.List   `tfsdk:"nameservers"`
+	DelegatedNameservers types.List   `tfsdk:"delegated_nameservers"`
+	SOA                  types.Object `tfsdk:"soa"`
+	DelegationStatus     types.String `tfsdk:"delegation_status"`
+	WaitForDelegation    types.Bool   `tfsdk:"wait_for_delegation"`
+	DelegationTimeout    types.Int64  `tfsdk:"delegation_timeout"`
 }
 
 type DnsRecordResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Domain      types.String `tfsdk:"domain"`
-	Record      types.String `tfsdk:"record"`
-	Type        types.String `tfsdk:"type"`
-	Content     types.String `tfsdk:"content"`
-	TTL         types.Int64  `tfsdk:"ttl"`
-	Priority    types.Int64  `tfsdk:"priority"`
-	Weight      types.Int64  `tfsdk:"weight"`
-	Port        types.Int64  `tfsdk:"port"`
-	Flags       types.Int64  `tfsdk:"flags"`
-	Tag         types.String `tfsdk:"tag"`
-	License     types.Int64  `tfsdk:"license"`
-	Choicer     types.Int64  `tfsdk:"choicer"`
-	Match       types.Int64  `tfsdk:"match"`
-	ContentID   types.String `tfsdk:"content_id"`
-	Status      types.String `tfsdk:"status"`
-	IsProtected types.Bool   `tfsdk:"is_protected"`
+	ID             types.String `tfsdk:"id"`
+	Domain         types.String `tfsdk:"domain"`
+	Record         types.String `tfsdk:"record"`
+	Type           types.String `tfsdk:"type"`
+	Content        types.String `tfsdk:"content"`
+	TTL            types.Int64  `tfsdk:"ttl"`
+	Priority       types.Int64  `tfsdk:"priority"`
+	Weight         types.Int64  `tfsdk:"weight"`
+	Port           types.Int64  `tfsdk:"port"`
+	Flags          types.Int64  `tfsdk:"flags"`
+	Tag            types.String `tfsdk:"tag"`
+	License        types.Int64  `tfsdk:"license"`
+	Choicer        types.Int64  `tfsdk:"choicer"`
+	Match          types.Int64  `tfsdk:"match"`
+	AllowOverwrite types.Bool   `tfsdk:"allow_overwrite"`
+	ContentID      types.String `tfsdk:"content_id"`
+	Status         types.String `tfsdk:"status"`
+	IsProtected    types.Bool   `tfsdk:"is_protected"`
+}
+
+type DnsZoneRecordsResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Domain     types.String `tfsdk:"domain"`
+	Records    types.Set    `tfsdk:"records"`
+	Ignore     types.List   `tfsdk:"ignore"`
+	ContentIDs types.Map    `tfsdk:"content_ids"`
+}
+
+type DnsZoneRecordModel struct {
+	Record   types.String `tfsdk:"record"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Port     types.Int64  `tfsdk:"port"`
+	Flags    types.Int64  `tfsdk:"flags"`
+	Tag      types.String `tfsdk:"tag"`
+	License  types.Int64  `tfsdk:"license"`
+	Choicer  types.Int64  `tfsdk:"choicer"`
+	Match    types.Int64  `tfsdk:"match"`
+}
+
+type DnsZoneFileDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Domain  types.String `tfsdk:"domain"`
+	Content types.String `tfsdk:"content"`
 }
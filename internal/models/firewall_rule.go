@@ -19,3 +19,48 @@ type FirewallRuleResourceModel struct {
 	Status        types.String `tfsdk:"status"`
 	CreatedAt     types.String `tfsdk:"created_at"`
 }
+
+type FirewallRulesetResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ZoneID    types.String `tfsdk:"zone_id"`
+	NetworkID types.String `tfsdk:"network_id"`
+	Rules     types.Set    `tfsdk:"rules"`
+}
+
+type FirewallRulesetRuleModel struct {
+	IPVersion     types.String `tfsdk:"ip_version"`
+	TrafficType   types.String `tfsdk:"traffic_type"`
+	Protocol      types.String `tfsdk:"protocol"`
+	IPSource      types.String `tfsdk:"ip_source"`
+	IPDestination types.String `tfsdk:"ip_destination"`
+	StartPort     types.Int64  `tfsdk:"start_port"`
+	EndPort       types.Int64  `tfsdk:"end_port"`
+	ICMPCode      types.Int64  `tfsdk:"icmp_code"`
+	ICMPType      types.Int64  `tfsdk:"icmp_type"`
+	PublicIPID    types.String `tfsdk:"public_ip_id"`
+}
+
+type SecurityGroupResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Rules       types.Set    `tfsdk:"rules"`
+	Version     types.Int64  `tfsdk:"version"`
+	Spec        types.Object `tfsdk:"spec"`
+}
+
+// SecurityGroupSpecModel is a security group's spec attribute, which
+// virakcloud_security_group_binding takes as its security_group.
+type SecurityGroupSpecModel struct {
+	ID      types.String `tfsdk:"id"`
+	Version types.Int64  `tfsdk:"version"`
+	Rules   types.Set    `tfsdk:"rules"`
+}
+
+type SecurityGroupBindingResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ZoneID        types.String `tfsdk:"zone_id"`
+	SecurityGroup types.Object `tfsdk:"security_group"`
+	NetworkIDs    types.Set    `tfsdk:"network_ids"`
+	RuleIDs       types.Map    `tfsdk:"rule_ids"`
+}
@@ -24,18 +24,41 @@ type InstanceNetwork struct {
 }
 
 type InstanceResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	ZoneID            types.String `tfsdk:"zone_id"`
-	ServiceOfferingID types.String `tfsdk:"service_offering_id"`
-	VMImageID         types.String `tfsdk:"vm_image_id"`
-	NetworkIDs        types.List   `tfsdk:"network_ids"`
-	Status            types.String `tfsdk:"status"`
-	Password          types.String `tfsdk:"password"`
-	Username          types.String `tfsdk:"username"`
-	IP                types.String `tfsdk:"ip"`
-	Networks          types.List   `tfsdk:"networks"`
-	DesiredState      types.String `tfsdk:"desired_state"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	ZoneID              types.String `tfsdk:"zone_id"`
+	ServiceOfferingID   types.String `tfsdk:"service_offering_id"`
+	VMImageID           types.String `tfsdk:"vm_image_id"`
+	NetworkIDs          types.List   `tfsdk:"network_ids"`
+	Status              types.String `tfsdk:"status"`
+	Password            types.String `tfsdk:"password"`
+	Username            types.String `tfsdk:"username"`
+	IP                  types.String `tfsdk:"ip"`
+	Networks            types.List   `tfsdk:"networks"`
+	DesiredState        types.String `tfsdk:"desired_state"`
+	RebootTrigger       types.Map    `tfsdk:"reboot_trigger"`
+	StopMode            types.String `tfsdk:"stop_mode"`
+	GracefulStopTimeout types.Int64  `tfsdk:"graceful_stop_timeout"`
+	DeleteStrategy      types.Object `tfsdk:"delete_strategy"`
+}
+
+type InstanceDeleteStrategyModel struct {
+	BootBeforeDelete types.Bool   `tfsdk:"boot_before_delete"`
+	Volumes          types.String `tfsdk:"volumes"`
+	StopBeforeDelete types.Bool   `tfsdk:"stop_before_delete"`
+	WaitForExpunge   types.Bool   `tfsdk:"wait_for_expunge"`
+}
+
+type InstancePowerActionModel struct {
+	ZoneID     types.String `tfsdk:"zone_id"`
+	InstanceID types.String `tfsdk:"instance_id"`
+}
+
+type InstanceStopActionModel struct {
+	ZoneID              types.String `tfsdk:"zone_id"`
+	InstanceID          types.String `tfsdk:"instance_id"`
+	StopMode            types.String `tfsdk:"stop_mode"`
+	GracefulStopTimeout types.Int64  `tfsdk:"graceful_stop_timeout"`
 }
 
 type InstanceOfferingsDataSourceModel struct {
@@ -12,6 +12,11 @@ type LoadBalancerResourceModel struct {
 	PublicPort  types.Int64  `tfsdk:"public_port"`
 	PrivatePort types.Int64  `tfsdk:"private_port"`
 	Status      types.String `tfsdk:"status"`
+	LiveStatus  types.String `tfsdk:"live_status"`
+	LiveUpdated types.String `tfsdk:"live_updated_at"`
+
+	BackendInstanceIDs types.Set `tfsdk:"backend_instance_ids"`
+	BackendAttachments types.Map `tfsdk:"backend_attachments"`
 }
 
 type LoadBalancerBackendResourceModel struct {
@@ -21,3 +26,22 @@ type LoadBalancerBackendResourceModel struct {
 	LoadBalancerID    types.String `tfsdk:"load_balancer_id"`
 	InstanceNetworkID types.String `tfsdk:"instance_network_id"`
 }
+
+type LoadBalancersDataSourceModel struct {
+	ID            types.String        `tfsdk:"id"`
+	ZoneID        types.String        `tfsdk:"zone_id"`
+	NetworkID     types.String        `tfsdk:"network_id"`
+	Name          types.String        `tfsdk:"name"`
+	PublicPort    types.Int64         `tfsdk:"public_port"`
+	LoadBalancers []LoadBalancerModel `tfsdk:"load_balancers"`
+}
+
+type LoadBalancerModel struct {
+	ID          types.String `tfsdk:"id"`
+	NetworkID   types.String `tfsdk:"network_id"`
+	Name        types.String `tfsdk:"name"`
+	Algorithm   types.String `tfsdk:"algorithm"`
+	PublicPort  types.Int64  `tfsdk:"public_port"`
+	PrivatePort types.Int64  `tfsdk:"private_port"`
+	Status      types.String `tfsdk:"status"`
+}
@@ -16,10 +16,12 @@ type NetworkResourceModel struct {
 	ZoneID            types.String `tfsdk:"zone_id"`
 	NetworkOfferingID types.String `tfsdk:"network_offering_id"`
 	Type              types.String `tfsdk:"type"`
+	CIDR              types.String `tfsdk:"cidr"`
 	Gateway           types.String `tfsdk:"gateway"`
 	Netmask           types.String `tfsdk:"netmask"`
 	Status            types.String `tfsdk:"status"`
 	Instances         types.List   `tfsdk:"instances"`
+	ForceDetach       types.Bool   `tfsdk:"force_detach_on_destroy"`
 }
 
 type NetworkFilterBlock struct {
@@ -43,3 +45,15 @@ type NetworkModel struct {
 	Netmask         types.String         `tfsdk:"netmask"`
 	NetworkOffering NetworkOfferingModel `tfsdk:"network_offering"`
 }
+
+type NetworkCIDRAllocatorDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Supernet          types.String `tfsdk:"supernet"`
+	PrefixLength      types.Int64  `tfsdk:"prefix_length"`
+	ZoneID            types.String `tfsdk:"zone_id"`
+	Exclude           types.List   `tfsdk:"exclude"`
+	ExcludeNetworkIDs types.Set    `tfsdk:"exclude_network_ids"`
+	CIDR              types.String `tfsdk:"cidr"`
+	Gateway           types.String `tfsdk:"gateway"`
+	Netmask           types.String `tfsdk:"netmask"`
+}
@@ -3,14 +3,38 @@ package models
 import "github.com/hashicorp/terraform-plugin-framework/types"
 
 type PortForwardingRuleResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ZoneID         types.String `tfsdk:"zone_id"`
+	NetworkID      types.String `tfsdk:"network_id"`
+	PublicIPID     types.String `tfsdk:"public_ip_id"`
+	Protocol       types.String `tfsdk:"protocol"`
+	PublicPort     types.Int64  `tfsdk:"public_port"`
+	PublicPortEnd  types.Int64  `tfsdk:"public_port_end"`
+	PrivatePort    types.Int64  `tfsdk:"private_port"`
+	PrivatePortEnd types.Int64  `tfsdk:"private_port_end"`
+	InstanceID     types.String `tfsdk:"instance_id"`
+	PrivateIP      types.String `tfsdk:"private_ip"`
+	RuleIDs        types.List   `tfsdk:"rule_ids"`
+	Status         types.String `tfsdk:"status"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+}
+
+type PortForwardingRulesDataSourceModel struct {
+	ID         types.String              `tfsdk:"id"`
+	ZoneID     types.String              `tfsdk:"zone_id"`
+	NetworkID  types.String              `tfsdk:"network_id"`
+	Protocol   types.String              `tfsdk:"protocol"`
+	PublicPort types.Int64               `tfsdk:"public_port"`
+	PrivateIP  types.String              `tfsdk:"private_ip"`
+	Rules      []PortForwardingRuleModel `tfsdk:"rules"`
+}
+
+type PortForwardingRuleModel struct {
 	ID          types.String `tfsdk:"id"`
-	ZoneID      types.String `tfsdk:"zone_id"`
 	NetworkID   types.String `tfsdk:"network_id"`
-	PublicIPID  types.String `tfsdk:"public_ip_id"`
 	Protocol    types.String `tfsdk:"protocol"`
 	PublicPort  types.Int64  `tfsdk:"public_port"`
 	PrivatePort types.Int64  `tfsdk:"private_port"`
-	InstanceID  types.String `tfsdk:"instance_id"`
 	PrivateIP   types.String `tfsdk:"private_ip"`
 	Status      types.String `tfsdk:"status"`
 	CreatedAt   types.String `tfsdk:"created_at"`
@@ -10,3 +10,39 @@ type PublicIPResourceModel struct {
 	IPAddress  types.String `tfsdk:"ip_address"`
 	Status     types.String `tfsdk:"status"`
 }
+
+type PublicIPAssociationResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ZoneID      types.String `tfsdk:"zone_id"`
+	NetworkID   types.String `tfsdk:"network_id"`
+	IPAddress   types.String `tfsdk:"ip_address"`
+	IsSourceNat types.Bool   `tfsdk:"is_source_nat"`
+}
+
+type PublicIPStaticNatResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ZoneID     types.String `tfsdk:"zone_id"`
+	NetworkID  types.String `tfsdk:"network_id"`
+	PublicIPID types.String `tfsdk:"public_ip_id"`
+	InstanceID types.String `tfsdk:"instance_id"`
+	IPAddress  types.String `tfsdk:"ip_address"`
+}
+
+type PublicIPsDataSourceModel struct {
+	ID              types.String    `tfsdk:"id"`
+	ZoneID          types.String    `tfsdk:"zone_id"`
+	NetworkID       types.String    `tfsdk:"network_id"`
+	IPAddress       types.String    `tfsdk:"ip_address"`
+	StaticNatTarget types.String    `tfsdk:"static_nat_target"`
+	PublicIPs       []PublicIPModel `tfsdk:"public_ips"`
+}
+
+type PublicIPModel struct {
+	ID               types.String `tfsdk:"id"`
+	NetworkID        types.String `tfsdk:"network_id"`
+	IPAddress        types.String `tfsdk:"ip_address"`
+	IsSourceNat      types.Bool   `tfsdk:"is_source_nat"`
+	StaticNatEnabled types.Bool   `tfsdk:"static_nat_enabled"`
+	StaticNat        types.List   `tfsdk:"static_nat"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
@@ -11,3 +11,37 @@ type SnapshotResourceModel struct {
 	CreatedAt  types.String `tfsdk:"created_at"`
 	Revert     types.Bool   `tfsdk:"revert"`
 }
+
+type SnapshotRevertActionModel struct {
+	ZoneID     types.String `tfsdk:"zone_id"`
+	InstanceID types.String `tfsdk:"instance_id"`
+	SnapshotID types.String `tfsdk:"snapshot_id"`
+}
+
+type SnapshotPolicyResourceModel struct {
+	ID               types.String            `tfsdk:"id"`
+	ZoneID           types.String            `tfsdk:"zone_id"`
+	InstanceID       types.String            `tfsdk:"instance_id"`
+	NamePrefix       types.String            `tfsdk:"name_prefix"`
+	Schedule         types.List              `tfsdk:"schedule"`
+	Retention        *SnapshotRetentionModel `tfsdk:"retention"`
+	PruneOnDestroy   types.Bool              `tfsdk:"prune_on_destroy"`
+	Snapshots        types.List              `tfsdk:"snapshots"`
+	LastReconciledAt types.String            `tfsdk:"last_reconciled_at"`
+	LastReport       types.List              `tfsdk:"last_report"`
+}
+
+type SnapshotRetentionModel struct {
+	Hourly  types.Int64 `tfsdk:"hourly"`
+	Daily   types.Int64 `tfsdk:"daily"`
+	Weekly  types.Int64 `tfsdk:"weekly"`
+	Monthly types.Int64 `tfsdk:"monthly"`
+}
+
+type SnapshotPolicySnapshotModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Interval types.String `tfsdk:"interval"`
+	Status   types.String `tfsdk:"status"`
+	TakenAt  types.String `tfsdk:"taken_at"`
+}
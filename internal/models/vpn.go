@@ -12,4 +12,19 @@ type NetworkVPNResourceModel struct {
 	Username     types.String `tfsdk:"username"`
 	Password     types.String `tfsdk:"password"`
 	Status       types.String `tfsdk:"status"`
+	NetworkCIDR  types.String `tfsdk:"network_cidr"`
+	ClientConfig types.Object `tfsdk:"client_config"`
+
+	RotationTrigger      types.String `tfsdk:"rotation_trigger"`
+	RotateAfter          types.String `tfsdk:"rotate_after"`
+	CredentialsRotatedAt types.String `tfsdk:"credentials_rotated_at"`
+}
+
+type NetworkVPNCredentialsEphemeralModel struct {
+	ZoneID       types.String `tfsdk:"zone_id"`
+	NetworkID    types.String `tfsdk:"network_id"`
+	IPAddress    types.String `tfsdk:"ip_address"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	PresharedKey types.String `tfsdk:"preshared_key"`
 }
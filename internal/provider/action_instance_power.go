@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+const (
+	instancePowerStart  = "start"
+	instancePowerStop   = "stop"
+	instancePowerReboot = "reboot"
+)
+
+// Ensure the implementation satisfies the action interfaces.
+var _ action.Action = &instancePowerAction{}
+var _ action.ActionWithConfigure = &instancePowerAction{}
+
+func NewInstanceStartAction(mutex *sync.Mutex) action.Action {
+	return &instancePowerAction{mutex: mutex, operation: instancePowerStart}
+}
+
+func NewInstanceStopAction(mutex *sync.Mutex) action.Action {
+	return &instancePowerAction{mutex: mutex, operation: instancePowerStop}
+}
+
+func NewInstanceRebootAction(mutex *sync.Mutex) action.Action {
+	return &instancePowerAction{mutex: mutex, operation: instancePowerReboot}
+}
+
+// instancePowerAction implements the virakcloud_instance_start, _stop and
+// _reboot actions, which differ only in the operation they perform.
+type instancePowerAction struct {
+	client    *http.Client
+	mutex     *sync.Mutex
+	operation string
+}
+
+func (a *instancePowerAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_" + a.operation
+}
+
+func (a *instancePowerAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"zone_id": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The ID of the zone where the instance resides.",
+		},
+		"instance_id": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The ID of the instance.",
+		},
+	}
+
+	description := ""
+	switch a.operation {
+	case instancePowerStart:
+		description = "Starts a stopped Virak Cloud instance and waits until it is running."
+	case instancePowerReboot:
+		description = "Reboots a running Virak Cloud instance and waits until it is running again. Stopped instances are left untouched."
+	case instancePowerStop:
+		description = "Stops a running Virak Cloud instance and waits until it is stopped."
+		attributes["stop_mode"] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "How the instance is stopped. Valid values: 'graceful' (default), 'force', 'graceful_then_force'.",
+			Validators: []validator.String{
+				stringvalidator.OneOf(helpers.StopModeGraceful, helpers.StopModeForce, helpers.StopModeGracefulThenForce),
+			},
+		}
+		attributes["graceful_stop_timeout"] = schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Seconds to wait for a graceful stop before failing or forcing the stop. Defaults to 600.",
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+		Attributes:          attributes,
+	}
+}
+
+func (a *instancePowerAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	a.client = client
+}
+
+func (a *instancePowerAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.operation == instancePowerStop {
+		var stopData models.InstanceStopActionModel
+		resp.Diagnostics.Append(req.Config.Get(ctx, &stopData)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		a.stop(stopData, resp)
+		return
+	}
+
+	var data models.InstancePowerActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	instanceID := data.InstanceID.ValueString()
+
+	switch a.operation {
+	case instancePowerStart:
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Starting instance %s", instanceID)})
+		if err := helpers.EnsureInstanceRunning(a.client, zoneID, instanceID, &resp.Diagnostics); err != nil {
+			return
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Instance %s is running", instanceID)})
+	case instancePowerReboot:
+		readResp, err := a.client.ShowInstance(zoneID, instanceID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance before reboot, got error: %s", err))
+			return
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Rebooting instance %s", instanceID)})
+		result := helpers.HandleInstanceReboot(a.client, zoneID, instanceID, readResp.Data.Status)
+		resp.Diagnostics.Append(result.Diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Instance %s status: %s", instanceID, result.Status.ValueString())})
+	}
+}
+
+func (a *instancePowerAction) stop(data models.InstanceStopActionModel, resp *action.InvokeResponse) {
+	instanceID := data.InstanceID.ValueString()
+	policy := helpers.NewStopPolicy(data.StopMode, data.GracefulStopTimeout)
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Stopping instance %s (mode: %s)", instanceID, policy.Mode)})
+	if err := helpers.EnsureInstanceStopped(a.client, data.ZoneID.ValueString(), instanceID, policy, &resp.Diagnostics); err != nil {
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Instance %s is stopped", instanceID)})
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the action interfaces.
+var _ action.Action = &snapshotRevertAction{}
+var _ action.ActionWithConfigure = &snapshotRevertAction{}
+
+func NewSnapshotRevertAction(mutex *sync.Mutex) action.Action {
+	return &snapshotRevertAction{mutex: mutex}
+}
+
+type snapshotRevertAction struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+func (a *snapshotRevertAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_revert"
+}
+
+func (a *snapshotRevertAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reverts a Virak Cloud instance to one of its snapshots.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the instance resides.",
+			},
+			"instance_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the instance to revert.",
+			},
+			"snapshot_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the snapshot to revert to.",
+			},
+		},
+	}
+}
+
+func (a *snapshotRevertAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	a.client = client
+}
+
+func (a *snapshotRevertAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var data models.SnapshotRevertActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Reverting instance %s to snapshot %s", data.InstanceID.ValueString(), data.SnapshotID.ValueString()),
+	})
+
+	if err := helpers.RevertToSnapshot(a.client, data.ZoneID.ValueString(), data.InstanceID.ValueString(), data.SnapshotID.ValueString(), &resp.Diagnostics); err != nil {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Instance %s reverted to snapshot %s", data.InstanceID.ValueString(), data.SnapshotID.ValueString()),
+	})
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ datasource.DataSource = &dnsZoneFileDataSource{}
+
+func NewDnsZoneFileDataSource() datasource.DataSource {
+	return &dnsZoneFileDataSource{}
+}
+
+type dnsZoneFileDataSource struct {
+	client *http.Client
+}
+
+func (d *dnsZoneFileDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_file"
+}
+
+func (d *dnsZoneFileDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the current records of a DNS domain as an RFC 1035 zone file. " +
+			"Use the `parse_zone_file` function to load a zone file back into `virakcloud_dns_zone_records`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain to export.",
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zone file.",
+			},
+		},
+	}
+}
+
+func (d *dnsZoneFileDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *dnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.DnsZoneFileDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+	recordsResp, err := d.client.GetRecords(domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s, got error: %s", domain, err))
+		return
+	}
+
+	var records []helpers.ZoneFileRecord
+	for _, rec := range recordsResp.Data {
+		for _, c := range rec.Content {
+			records = append(records, helpers.ZoneFileRecord{
+				Name:    helpers.NormalizeDNSRecordName(rec.Name, domain),
+				Type:    strings.ToUpper(rec.Type),
+				Content: c.ContentRaw,
+				TTL:     int64(rec.TTL),
+			})
+		}
+	}
+
+	content, incomplete := helpers.RenderZoneFile(domain, records)
+	if len(incomplete) > 0 {
+		var names []string
+		for _, rec := range incomplete {
+			names = append(names, rec.Name+" "+rec.Type)
+		}
+		resp.Diagnostics.AddWarning(
+			"Incomplete Zone File Records",
+			fmt.Sprintf("The API did not return priority, weight, port or CAA fields for %s; these records are commented out in the zone file with \"?\" in place of the missing values.", strings.Join(names, ", ")),
+		)
+	}
+
+	data.Content = types.StringValue(content)
+	data.ID = types.StringValue(domain + "_zone_file")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
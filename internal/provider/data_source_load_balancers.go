@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ datasource.DataSource = &loadBalancersDataSource{}
+
+func NewLoadBalancersDataSource() datasource.DataSource {
+	return &loadBalancersDataSource{}
+}
+
+type loadBalancersDataSource struct {
+	client *http.Client
+}
+
+func (d *loadBalancersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_load_balancers"
+}
+
+func (d *loadBalancersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists load balancer rules in a zone, optionally narrowed to one network, name or public port.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone to list load balancer rules for.",
+			},
+			"network_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules on this network. Defaults to every L3 network in the zone.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules with this name.",
+			},
+			"public_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules on this public port.",
+			},
+			"load_balancers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching load balancer rules.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the load balancer rule.",
+						},
+						"network_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the network the rule belongs to.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the rule.",
+						},
+						"algorithm": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The load balancing algorithm.",
+						},
+						"public_port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The public port.",
+						},
+						"private_port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The private port of the backends.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the rule.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *loadBalancersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *loadBalancersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.LoadBalancersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	networkIDs, err := dataSourceNetworkIDs(d.client, zoneID, data.NetworkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list networks for zone %s, got error: %s", zoneID, err))
+		return
+	}
+
+	data.LoadBalancers = []models.LoadBalancerModel{}
+	for _, networkID := range networkIDs {
+		listResp, err := d.client.ListLoadBalancerRules(zoneID, networkID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list load balancer rules for network %s, got error: %s", networkID, err))
+			return
+		}
+		for _, rule := range listResp.Data {
+			if !data.Name.IsNull() && rule.Name != data.Name.ValueString() {
+				continue
+			}
+			if !data.PublicPort.IsNull() && int64(rule.PublicPort) != data.PublicPort.ValueInt64() {
+				continue
+			}
+			data.LoadBalancers = append(data.LoadBalancers, models.LoadBalancerModel{
+				ID:          types.StringValue(rule.ID),
+				NetworkID:   types.StringValue(networkID),
+				Name:        types.StringValue(rule.Name),
+				Algorithm:   types.StringValue(rule.Algorithm),
+				PublicPort:  types.Int64Value(int64(rule.PublicPort)),
+				PrivatePort: types.Int64Value(int64(rule.PrivatePort)),
+				Status:      types.StringValue(rule.Status),
+			})
+		}
+	}
+
+	data.ID = types.StringValue(dataSourceListID(zoneID, data.NetworkID, "load_balancers"))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dataSourceNetworkIDs returns the configured network, or every L3 network in
+// the zone when none is set.
+func dataSourceNetworkIDs(client *http.Client, zoneID string, networkID types.String) ([]string, error) {
+	if !networkID.IsNull() && networkID.ValueString() != "" {
+		return []string{networkID.ValueString()}, nil
+	}
+	return helpers.ListZoneL3NetworkIDs(client, zoneID)
+}
+
+func dataSourceListID(zoneID string, networkID types.String, suffix string) string {
+	if !networkID.IsNull() && networkID.ValueString() != "" {
+		return networkID.ValueString() + "_" + suffix
+	}
+	return zoneID + "_" + suffix
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ datasource.DataSource = &networkCIDRAllocatorDataSource{}
+
+func NewNetworkCIDRAllocatorDataSource() datasource.DataSource {
+	return &networkCIDRAllocatorDataSource{}
+}
+
+type networkCIDRAllocatorDataSource struct {
+	client *http.Client
+}
+
+func (d *networkCIDRAllocatorDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_cidr_allocator"
+}
+
+func (d *networkCIDRAllocatorDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Proposes the next free subnet of a given size inside a supernet, skipping subnets used by the zone's L3 networks and any listed exclusions. " +
+			"The API only reports the subnet of networks that have instances attached, so list other planned or empty networks in `exclude`. " +
+			"Once instances attach to a network created from `cidr`, its subnet counts as used and the proposal moves on, which would replace that network. " +
+			"Pin the result in the network with `lifecycle { ignore_changes = [cidr] }`, or list the network in `exclude_network_ids` once its ID is known.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"supernet": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The IPv4 range to allocate from, e.g. `10.0.0.0/16`.",
+			},
+			"prefix_length": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The prefix length of the subnet to allocate, e.g. `24`.",
+				Validators: []validator.Int64{
+					int64validator.Between(helpers.MinNetworkPrefixLength, helpers.MaxNetworkPrefixLength),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "If set, subnets used by L3 networks in this zone are skipped.",
+			},
+			"exclude": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional CIDRs that must not be allocated.",
+			},
+			"exclude_network_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of networks in `zone_id` whose subnets are not counted as used, such as the network that consumes this allocation.",
+			},
+			"cidr": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The proposed subnet.",
+			},
+			"gateway": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The gateway address of the proposed subnet.",
+			},
+			"netmask": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The netmask of the proposed subnet.",
+			},
+		},
+	}
+}
+
+func (d *networkCIDRAllocatorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *networkCIDRAllocatorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.NetworkCIDRAllocatorDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	supernet, err := netip.ParsePrefix(data.Supernet.ValueString())
+	if err != nil || !supernet.Addr().Is4() {
+		resp.Diagnostics.AddAttributeError(path.Root("supernet"), "Invalid Supernet", fmt.Sprintf("%q is not a valid IPv4 CIDR.", data.Supernet.ValueString()))
+		return
+	}
+	supernet = supernet.Masked()
+
+	var used []netip.Prefix
+
+	var excluded []string
+	if !data.Exclude.IsNull() {
+		resp.Diagnostics.Append(data.Exclude.ElementsAs(ctx, &excluded, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	for i, cidr := range excluded {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("exclude").AtListIndex(i), "Invalid CIDR", fmt.Sprintf("%q is not a valid CIDR.", cidr))
+			return
+		}
+		used = append(used, prefix.Masked())
+	}
+
+	skipped := make(map[string]struct{})
+	if !data.ExcludeNetworkIDs.IsNull() {
+		var networkIDs []string
+		resp.Diagnostics.Append(data.ExcludeNetworkIDs.ElementsAs(ctx, &networkIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, networkID := range networkIDs {
+			skipped[networkID] = struct{}{}
+		}
+	}
+
+	if !data.ZoneID.IsNull() {
+		existing, err := helpers.ListZoneNetworkPrefixes(d.client, data.ZoneID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list networks in zone %s, got error: %s", data.ZoneID.ValueString(), err))
+			return
+		}
+		for _, network := range existing {
+			if _, ok := skipped[network.ID]; ok {
+				continue
+			}
+			used = append(used, network.Prefix)
+		}
+	}
+
+	bits := int(data.PrefixLength.ValueInt64())
+	if bits < supernet.Bits() {
+		resp.Diagnostics.AddAttributeError(path.Root("prefix_length"), "Invalid Prefix Length", fmt.Sprintf("A /%d subnet does not fit in %s.", bits, supernet))
+		return
+	}
+
+	allocated, ok := helpers.NextFreeSubnet(supernet, bits, used)
+	if !ok {
+		resp.Diagnostics.AddError("No Free Subnet", fmt.Sprintf("No free /%d subnet is left in %s.", bits, supernet))
+		return
+	}
+
+	gateway, netmask := helpers.CIDRGatewayNetmask(allocated)
+	data.ID = types.StringValue(fmt.Sprintf("%s/%d", supernet, bits))
+	data.CIDR = types.StringValue(allocated.String())
+	data.Gateway = types.StringValue(gateway)
+	data.Netmask = types.StringValue(netmask)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ datasource.DataSource = &portForwardingRulesDataSource{}
+
+func NewPortForwardingRulesDataSource() datasource.DataSource {
+	return &portForwardingRulesDataSource{}
+}
+
+type portForwardingRulesDataSource struct {
+	client *http.Client
+}
+
+func (d *portForwardingRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_port_forwarding_rules"
+}
+
+func (d *portForwardingRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists port forwards in a zone, optionally narrowed to one network, protocol, public port or target address. " +
+			"Each API forward covers one protocol and port, so a ranged `virakcloud_port_forwarding_rule` appears as several entries.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone to list port forwards for.",
+			},
+			"network_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list forwards on this network. Defaults to every L3 network in the zone.",
+			},
+			"protocol": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list forwards using this protocol (`TCP` or `UDP`).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.PortForwardProtocolTCP, helpers.PortForwardProtocolUDP),
+				},
+			},
+			"public_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only list forwards on this public port.",
+			},
+			"private_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list forwards to this private IP address.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching port forwards.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the port forward.",
+						},
+						"network_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the network the forward belongs to.",
+						},
+						"protocol": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The protocol.",
+						},
+						"public_port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The public port.",
+						},
+						"private_port": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The private port.",
+						},
+						"private_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The private IP address traffic is forwarded to.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the forward.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The creation timestamp of the forward.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *portForwardingRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *portForwardingRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.PortForwardingRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	networkIDs, err := dataSourceNetworkIDs(d.client, zoneID, data.NetworkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list networks for zone %s, got error: %s", zoneID, err))
+		return
+	}
+
+	data.Rules = []models.PortForwardingRuleModel{}
+	for _, networkID := range networkIDs {
+		listResp, err := d.client.ListPortForwards(zoneID, networkID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list port forwards for network %s, got error: %s", networkID, err))
+			return
+		}
+		helpers.SortPortForwardRules(listResp.Data)
+		for _, rule := range listResp.Data {
+			if !data.Protocol.IsNull() && rule.Protocol != data.Protocol.ValueString() {
+				continue
+			}
+			if !data.PublicPort.IsNull() && int64(rule.PublicPort) != data.PublicPort.ValueInt64() {
+				continue
+			}
+			if !data.PrivateIP.IsNull() && rule.PrivateIP != data.PrivateIP.ValueString() {
+				continue
+			}
+			data.Rules = append(data.Rules, models.PortForwardingRuleModel{
+				ID:          types.StringValue(rule.ID),
+				NetworkID:   types.StringValue(networkID),
+				Protocol:    types.StringValue(rule.Protocol),
+				PublicPort:  types.Int64Value(int64(rule.PublicPort)),
+				PrivatePort: types.Int64Value(int64(rule.PrivatePort)),
+				PrivateIP:   types.StringValue(rule.PrivateIP),
+				Status:      types.StringValue(rule.Status),
+				CreatedAt:   types.StringValue(fmt.Sprintf("%d", rule.CreatedAt)),
+			})
+		}
+	}
+
+	data.ID = types.StringValue(dataSourceListID(zoneID, data.NetworkID, "port_forwarding_rules"))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
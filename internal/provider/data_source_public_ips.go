@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+)
+
+var _ datasource.DataSource = &publicIPsDataSource{}
+
+func NewPublicIPsDataSource() datasource.DataSource {
+	return &publicIPsDataSource{}
+}
+
+type publicIPsDataSource struct {
+	client *http.Client
+}
+
+func (d *publicIPsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_ips"
+}
+
+func (d *publicIPsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists public IPs in a zone, optionally narrowed to one network, address or static NAT target.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone to list public IPs for.",
+			},
+			"network_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list public IPs on this network. Defaults to every L3 network in the zone.",
+			},
+			"ip_address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the public IP with this address.",
+			},
+			"static_nat_target": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list public IPs whose `static_nat` contains this value.",
+			},
+			"public_ips": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching public IPs.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the public IP.",
+						},
+						"network_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the network the public IP is associated with.",
+						},
+						"ip_address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The public IP address.",
+						},
+						"is_source_nat": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the address is the network's source NAT address.",
+						},
+						"static_nat_enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether static NAT is enabled on the address.",
+						},
+						"static_nat": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The static NAT targets as reported by the API.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The creation timestamp of the public IP.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *publicIPsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *publicIPsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data models.PublicIPsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	networkIDs, err := dataSourceNetworkIDs(d.client, zoneID, data.NetworkID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list networks for zone %s, got error: %s", zoneID, err))
+		return
+	}
+
+	data.PublicIPs = []models.PublicIPModel{}
+	for _, networkID := range networkIDs {
+		listResp, err := d.client.ListNetworkPublicIps(zoneID, networkID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list public IPs for network %s, got error: %s", networkID, err))
+			return
+		}
+		for _, ip := range listResp.Data {
+			if !data.IPAddress.IsNull() && ip.IpAddress != data.IPAddress.ValueString() {
+				continue
+			}
+			if !data.StaticNatTarget.IsNull() && !slices.Contains(ip.StaticNat, data.StaticNatTarget.ValueString()) {
+				continue
+			}
+
+			staticNat, diags := types.ListValueFrom(ctx, types.StringType, ip.StaticNat)
+			resp.Diagnostics.Append(diags...)
+
+			networkOfIP := ip.NetworkID
+			if networkOfIP == "" {
+				networkOfIP = networkID
+			}
+			data.PublicIPs = append(data.PublicIPs, models.PublicIPModel{
+				ID:               types.StringValue(ip.ID),
+				NetworkID:        types.StringValue(networkOfIP),
+				IPAddress:        types.StringValue(ip.IpAddress),
+				IsSourceNat:      types.BoolValue(ip.IsSourceNat),
+				StaticNatEnabled: types.BoolValue(ip.StaticNatEnable),
+				StaticNat:        staticNat,
+				CreatedAt:        types.StringValue(fmt.Sprintf("%d", ip.CreatedAt)),
+			})
+		}
+	}
+
+	data.ID = types.StringValue(dataSourceListID(zoneID, data.NetworkID, "public_ips"))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+)
+
+// Ensure the implementation satisfies the ephemeral resource interfaces.
+var _ ephemeral.EphemeralResource = &networkVPNCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &networkVPNCredentialsEphemeralResource{}
+
+func NewNetworkVPNCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &networkVPNCredentialsEphemeralResource{}
+}
+
+type networkVPNCredentialsEphemeralResource struct {
+	client *http.Client
+}
+
+func (e *networkVPNCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_vpn_credentials"
+}
+
+func (e *networkVPNCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the current VPN credentials of a network without storing them in state or plan files. " +
+			"Use it after `virakcloud_network_vpn` rotates credentials to hand the new secrets to other providers or write-only attributes.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the network is located.",
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the network.",
+			},
+			"ip_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The VPN server IP address.",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The VPN username.",
+			},
+			"password": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The VPN password.",
+			},
+			"preshared_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The IPsec pre-shared key.",
+			},
+		},
+	}
+}
+
+func (e *networkVPNCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	e.client = client
+}
+
+func (e *networkVPNCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data models.NetworkVPNCredentialsEphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	detailsResp, err := e.client.GetNetworkVpnDetails(data.ZoneID.ValueString(), data.NetworkID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read VPN credentials, got error: %s", err))
+		return
+	}
+
+	data.IPAddress = types.StringValue(detailsResp.Data.IPAddress)
+	data.Username = types.StringValue(detailsResp.Data.Username)
+	data.Password = types.StringValue(detailsResp.Data.Password)
+	data.PresharedKey = types.StringValue(detailsResp.Data.PresharedKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ function.Function = &inspectCertificateFunction{}
+
+// NewInspectCertificateFunction returns a function that inspects a PEM
+// certificate locally. The Virak Cloud API has no certificate store and load
+// balancers only forward TCP, so there is nothing to upload. It is a function
+// rather than a data source so that the private key never reaches state.
+func NewInspectCertificateFunction() function.Function {
+	return &inspectCertificateFunction{}
+}
+
+type inspectCertificateFunction struct{}
+
+func (f *inspectCertificateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "inspect_certificate"
+}
+
+func (f *inspectCertificateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check a PEM certificate, chain and private key and return the certificate's details.",
+		MarkdownDescription: "Checks that a PEM certificate, chain and private key belong together and returns the certificate's details, including `not_after` for `check` blocks. " +
+			"Nothing is uploaded: the Virak Cloud API has no certificate store and load balancers forward raw TCP only, so backends must terminate TLS themselves. " +
+			"The result never contains the private key. Expiry is not checked; compare `not_after` against `timestamp()` in a `check` block.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "certificate_pem",
+				MarkdownDescription: "The PEM-encoded leaf certificate.",
+			},
+			function.StringParameter{
+				Name:                "certificate_chain_pem",
+				AllowNullValue:      true,
+				MarkdownDescription: "The PEM-encoded intermediate certificates, issuer of the leaf first, or `null`.",
+			},
+			function.StringParameter{
+				Name:                "private_key_pem",
+				MarkdownDescription: "The PEM-encoded private key matching the certificate.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: certificateInfoAttrTypes(),
+		},
+	}
+}
+
+func (f *inspectCertificateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certificatePEM, privateKeyPEM string
+	var chainPEM types.String
+	resp.Error = req.Arguments.Get(ctx, &certificatePEM, &chainPEM, &privateKeyPEM)
+	if resp.Error != nil {
+		return
+	}
+
+	info, err := helpers.ParseCertificateBundle(certificatePEM, chainPEM.ValueString(), privateKeyPEM)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	dnsNames, diags := types.ListValueFrom(ctx, types.StringType, info.DNSNames)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	result, diags := types.ObjectValueFrom(ctx, certificateInfoAttrTypes(), models.CertificateInfoModel{
+		FingerprintSHA256: types.StringValue(info.FingerprintSHA256),
+		Subject:           types.StringValue(info.Subject),
+		Issuer:            types.StringValue(info.Issuer),
+		DNSNames:          dnsNames,
+		NotBefore:         types.StringValue(info.NotBefore.UTC().Format(time.RFC3339)),
+		NotAfter:          types.StringValue(info.NotAfter.UTC().Format(time.RFC3339)),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+func certificateInfoAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"fingerprint_sha256": types.StringType,
+		"subject":            types.StringType,
+		"issuer":             types.StringType,
+		"dns_names":          types.ListType{ElemType: types.StringType},
+		"not_before":         types.StringType,
+		"not_after":          types.StringType,
+	}
+}
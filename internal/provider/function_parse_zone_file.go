@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ function.Function = &parseZoneFileFunction{}
+
+func NewParseZoneFileFunction() function.Function {
+	return &parseZoneFileFunction{}
+}
+
+type parseZoneFileFunction struct{}
+
+func (f *parseZoneFileFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_zone_file"
+}
+
+func (f *parseZoneFileFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse an RFC 1035 zone file into DNS records.",
+		MarkdownDescription: "Parses a BIND-style zone file into records that can be passed to `virakcloud_dns_zone_records.records`. " +
+			"A, AAAA, CNAME, MX, TXT, SRV, CAA and NS records are supported; SOA and apex NS records are skipped because Virak DNS manages them; NS records delegating subdomains are kept. " +
+			"Names are returned relative to the domain, with `@` for the apex.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "content",
+				MarkdownDescription: "The zone file, e.g. `file(\"example.com.zone\")`.",
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The domain the zone file describes; also the default `$ORIGIN`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: helpers.GetDnsZoneRecordObjectType(),
+		},
+	}
+}
+
+func (f *parseZoneFileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content, domain string
+	resp.Error = req.Arguments.Get(ctx, &content, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := helpers.ParseZoneFile(content, domain)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	records := make([]models.DnsZoneRecordModel, 0, len(parsed))
+	for _, rec := range parsed {
+		records = append(records, models.DnsZoneRecordModel{
+			Record:   types.StringValue(rec.Name),
+			Type:     types.StringValue(rec.Type),
+			Content:  types.StringValue(rec.Content),
+			TTL:      types.Int64Value(rec.TTL),
+			Priority: types.Int64PointerValue(rec.Priority),
+			Weight:   types.Int64PointerValue(rec.Weight),
+			Port:     types.Int64PointerValue(rec.Port),
+			Flags:    types.Int64PointerValue(rec.Flags),
+			Tag:      types.StringPointerValue(rec.Tag),
+			License:  types.Int64Null(),
+			Choicer:  types.Int64Null(),
+			Match:    types.Int64Null(),
+		})
+	}
+
+	result, diags := types.ListValueFrom(ctx, helpers.GetDnsZoneRecordObjectType(), records)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateInfo describes a validated certificate bundle.
+type CertificateInfo struct {
+	FingerprintSHA256 string
+	Subject           string
+	Issuer            string
+	DNSNames          []string
+	NotBefore         time.Time
+	NotAfter          time.Time
+}
+
+// ParseCertificateBundle validates a PEM leaf certificate, an optional PEM
+// chain and a PEM private key. It checks that the key matches the leaf and
+// that the leaf is signed by the first chain certificate.
+func ParseCertificateBundle(certificatePEM, chainPEM, privateKeyPEM string) (*CertificateInfo, error) {
+	certs, err := parsePEMCertificates(certificatePEM)
+	if err != nil {
+		return nil, fmt.Errorf("certificate: %w", err)
+	}
+	if len(certs) != 1 {
+		return nil, fmt.Errorf("certificate: expected exactly one certificate, found %d; put intermediates in the chain", len(certs))
+	}
+	leaf := certs[0]
+
+	if _, err := tls.X509KeyPair([]byte(certificatePEM), []byte(privateKeyPEM)); err != nil {
+		return nil, fmt.Errorf("private key does not match the certificate: %w", err)
+	}
+
+	if strings.TrimSpace(chainPEM) != "" {
+		chain, err := parsePEMCertificates(chainPEM)
+		if err != nil {
+			return nil, fmt.Errorf("chain: %w", err)
+		}
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("chain: no certificates found")
+		}
+		if err := leaf.CheckSignatureFrom(chain[0]); err != nil {
+			return nil, fmt.Errorf("chain: certificate is not signed by %q: %w", chain[0].Subject.String(), err)
+		}
+	}
+
+	sum := sha256.Sum256(leaf.Raw)
+	return &CertificateInfo{
+		FingerprintSHA256: hex.EncodeToString(sum[:]),
+		Subject:           leaf.Subject.String(),
+		Issuer:            leaf.Issuer.String(),
+		DNSNames:          leaf.DNSNames,
+		NotBefore:         leaf.NotBefore,
+		NotAfter:          leaf.NotAfter,
+	}, nil
+}
+
+func parsePEMCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return certs, nil
+}
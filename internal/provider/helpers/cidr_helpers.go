@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/virak-cloud/cli/pkg/http"
+)
+
+// MinNetworkPrefixLength and MaxNetworkPrefixLength bound the size of an L3
+// network: anything smaller than a /30 leaves no room for a gateway and a host.
+const (
+	MinNetworkPrefixLength = 8
+	MaxNetworkPrefixLength = 30
+)
+
+// NetworkPrefix is the subnet of an existing network in a zone.
+type NetworkPrefix struct {
+	ID     string
+	Name   string
+	Prefix netip.Prefix
+}
+
+// ParseNetworkCIDR parses an IPv4 network CIDR such as "10.0.1.0/24". Host bits
+// must be zero and the prefix must leave room for a gateway and hosts.
+func ParseNetworkCIDR(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%q is not a valid CIDR: %w", cidr, err)
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("%q is not an IPv4 CIDR", cidr)
+	}
+	if prefix.Masked() != prefix {
+		return netip.Prefix{}, fmt.Errorf("%q has host bits set, did you mean %q?", cidr, prefix.Masked().String())
+	}
+	if prefix.Bits() < MinNetworkPrefixLength || prefix.Bits() > MaxNetworkPrefixLength {
+		return netip.Prefix{}, fmt.Errorf("%q must have a prefix length between /%d and /%d", cidr, MinNetworkPrefixLength, MaxNetworkPrefixLength)
+	}
+	return prefix, nil
+}
+
+// CIDRGatewayNetmask derives the gateway (the first usable address) and the
+// dotted-decimal netmask of an IPv4 prefix.
+func CIDRGatewayNetmask(prefix netip.Prefix) (gateway, netmask string) {
+	gateway = prefix.Masked().Addr().Next().String()
+
+	mask := uint32(0xffffffff) << (32 - prefix.Bits())
+	netmask = netip.AddrFrom4([4]byte{byte(mask >> 24), byte(mask >> 16), byte(mask >> 8), byte(mask)}).String()
+	return gateway, netmask
+}
+
+// PrefixFromGatewayNetmask returns the subnet described by a gateway and a
+// dotted-decimal netmask, or false if either is invalid.
+func PrefixFromGatewayNetmask(gateway, netmask string) (netip.Prefix, bool) {
+	addr, err := netip.ParseAddr(gateway)
+	if err != nil || !addr.Is4() {
+		return netip.Prefix{}, false
+	}
+	mask, err := netip.ParseAddr(netmask)
+	if err != nil || !mask.Is4() {
+		return netip.Prefix{}, false
+	}
+
+	b := mask.As4()
+	value := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	bits := 0
+	for value&0x80000000 != 0 {
+		bits++
+		value <<= 1
+	}
+	if value != 0 {
+		// Non-contiguous netmask.
+		return netip.Prefix{}, false
+	}
+
+	return netip.PrefixFrom(addr, bits).Masked(), true
+}
+
+// ListZoneNetworkPrefixes returns the subnets of the L3 networks in a zone.
+// The API only reports a network's addressing through its instance
+// attachments, so networks without attached instances are not included, and
+// neither are networks that only exist in Terraform plans or state. Overlap
+// detection built on this is therefore best-effort.
+func ListZoneNetworkPrefixes(client *http.Client, zoneID string) ([]NetworkPrefix, error) {
+	networksResp, err := client.ListNetworks(zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefixes []NetworkPrefix
+	for _, network := range networksResp.Data {
+		for _, attachment := range network.InstanceNetwork {
+			prefix, ok := PrefixFromGatewayNetmask(attachment.Network.IPConfig.Gateway, attachment.Network.IPConfig.Netmask)
+			if !ok {
+				continue
+			}
+			prefixes = append(prefixes, NetworkPrefix{ID: network.ID, Name: network.Name, Prefix: prefix})
+			break
+		}
+	}
+
+	return prefixes, nil
+}
+
+// FindNetworkPrefix returns the subnet of a single network, read from its
+// instance attachments. It returns false if the network has none.
+func FindNetworkPrefix(client *http.Client, zoneID, networkID string) (netip.Prefix, bool) {
+	networkResp, err := client.ShowNetwork(zoneID, networkID)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	for _, attachment := range networkResp.Data.InstanceNetwork {
+		if prefix, ok := PrefixFromGatewayNetmask(attachment.Network.IPConfig.Gateway, attachment.Network.IPConfig.Netmask); ok {
+			return prefix, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// NextFreeSubnet returns the lowest subnet of the given prefix length inside
+// supernet that does not overlap any of the used prefixes.
+func NextFreeSubnet(supernet netip.Prefix, bits int, used []netip.Prefix) (netip.Prefix, bool) {
+	supernet = supernet.Masked()
+	if bits < supernet.Bits() || bits > 32 {
+		return netip.Prefix{}, false
+	}
+
+	sorted := make([]netip.Prefix, 0, len(used))
+	for _, prefix := range used {
+		if prefix.Overlaps(supernet) {
+			sorted = append(sorted, prefix.Masked())
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Addr().Less(sorted[j].Addr())
+	})
+
+	candidate := netip.PrefixFrom(supernet.Addr(), bits)
+	for supernet.Contains(candidate.Addr()) {
+		conflict, found := netip.Prefix{}, false
+		for _, prefix := range sorted {
+			if prefix.Overlaps(candidate) {
+				conflict, found = prefix, true
+				break
+			}
+		}
+		if !found {
+			return candidate, true
+		}
+
+		// Skip past whichever of the candidate and the conflicting prefix
+		// ends last, then realign to the requested size.
+		next, ok := nextPrefixAfter(candidate, conflict)
+		if !ok {
+			return netip.Prefix{}, false
+		}
+		candidate = next
+	}
+
+	return netip.Prefix{}, false
+}
+
+func nextPrefixAfter(candidate, conflict netip.Prefix) (netip.Prefix, bool) {
+	end := lastAddr(candidate)
+	if conflictEnd := lastAddr(conflict); end.Less(conflictEnd) {
+		end = conflictEnd
+	}
+	start := end.Next()
+	if !start.IsValid() {
+		return netip.Prefix{}, false
+	}
+
+	next := netip.PrefixFrom(start, candidate.Bits()).Masked()
+	for next.Addr().Less(start) {
+		advanced := lastAddr(next).Next()
+		if !advanced.IsValid() {
+			return netip.Prefix{}, false
+		}
+		next = netip.PrefixFrom(advanced, candidate.Bits())
+	}
+	return next, true
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().As4()
+	value := uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	value |= uint32(0xffffffff) >> prefix.Bits()
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
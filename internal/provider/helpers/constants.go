@@ -24,4 +24,37 @@ const (
 	VolumeStatusAttaching = "ATTACHING"
 
 	NetworkStatusActive = "Active"
+
+	StopModeGraceful          = "graceful"
+	StopModeForce             = "force"
+	StopModeGracefulThenForce = "graceful_then_force"
+
+	DefaultGracefulStopTimeout = 600 * time.Second
+
+	DeleteVolumesDetach = "detach"
+	DeleteVolumesDelete = "delete"
+	DeleteVolumesNone   = "none"
+
+	SnapshotStatusReady = "READY"
+
+	FirewallIPv4           = "ipv4"
+	FirewallIPv6           = "ipv6"
+	FirewallTrafficIngress = "Ingress"
+	FirewallTrafficEgress  = "Egress"
+	FirewallProtocolTCP    = "TCP"
+	FirewallProtocolUDP    = "UDP"
+	FirewallProtocolICMP   = "ICMP"
+
+	SnapshotIntervalHourly  = "hourly"
+	SnapshotIntervalDaily   = "daily"
+	SnapshotIntervalWeekly  = "weekly"
+	SnapshotIntervalMonthly = "monthly"
+
+	DelegationStatusDelegated    = "delegated"
+	DelegationStatusPartial      = "partial"
+	DelegationStatusNotDelegated = "not_delegated"
+	DelegationStatusUnknown      = "unknown"
+
+	DefaultDelegationTimeout      = 1800 * time.Second
+	DefaultDelegationPollInterval = 30 * time.Second
 )
@@ -0,0 +1,170 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NSResolver looks up the NS records of a name. *net.Resolver satisfies it;
+// tests can substitute a fake.
+type NSResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// DNSSOA is the parsed content of a zone's SOA record.
+type DNSSOA struct {
+	PrimaryNS  string
+	Hostmaster string
+	Serial     int64
+	Refresh    int64
+	Retry      int64
+	Expire     int64
+	MinimumTTL int64
+}
+
+// NormalizeNameservers lower-cases nameserver names, drops trailing dots and
+// empty entries, and returns them sorted without duplicates.
+func NormalizeNameservers(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+		if name != "" {
+			normalized = append(normalized, name)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// CompareDelegation returns the delegation status of a domain whose assigned
+// nameservers are assigned and whose public NS records are delegated.
+func CompareDelegation(assigned, delegated []string) string {
+	assigned, delegated = NormalizeNameservers(assigned), NormalizeNameservers(delegated)
+	switch {
+	case len(assigned) == 0:
+		return DelegationStatusUnknown
+	case slices.Equal(assigned, delegated):
+		return DelegationStatusDelegated
+	}
+	for _, name := range delegated {
+		if slices.Contains(assigned, name) {
+			return DelegationStatusPartial
+		}
+	}
+	return DelegationStatusNotDelegated
+}
+
+// LookupDelegation returns the public NS records of domain, normalised.
+func LookupDelegation(ctx context.Context, resolver NSResolver, domain string) ([]string, error) {
+	records, err := resolver.LookupNS(ctx, strings.TrimSuffix(domain, ".")+".")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(records))
+	for _, ns := range records {
+		names = append(names, ns.Host)
+	}
+	return NormalizeNameservers(names), nil
+}
+
+// WaitForDelegation polls the public NS records of domain until they match
+// assigned, or timeout elapses. Lookup errors, such as NXDOMAIN while the
+// registrar change propagates, are retried. It returns the last NS records
+// seen.
+func WaitForDelegation(ctx context.Context, resolver NSResolver, domain string, assigned []string, timeout, interval time.Duration) ([]string, error) {
+	if len(NormalizeNameservers(assigned)) == 0 {
+		return nil, fmt.Errorf("no nameservers are assigned to %s", domain)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var delegated []string
+	var lastErr error
+	for {
+		delegated, lastErr = LookupDelegation(ctx, resolver, domain)
+		if lastErr == nil && CompareDelegation(assigned, delegated) == DelegationStatusDelegated {
+			return delegated, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return delegated, fmt.Errorf("delegation of %s did not complete within %s, last lookup failed: %s", domain, timeout, lastErr)
+			}
+			return delegated, fmt.Errorf("delegation of %s did not complete within %s: public nameservers are [%s], expected [%s]",
+				domain, timeout, strings.Join(delegated, ", "), strings.Join(NormalizeNameservers(assigned), ", "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ParseSOAContent parses SOA record content of the form
+// "mname rname serial refresh retry expire minimum".
+func ParseSOAContent(content string) (*DNSSOA, error) {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(content))
+	if len(fields) != 7 {
+		return nil, fmt.Errorf("expected 7 SOA fields, got %d in %q", len(fields), content)
+	}
+
+	var numbers [5]int64
+	for i, field := range fields[2:] {
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SOA field %q in %q", field, content)
+		}
+		numbers[i] = n
+	}
+
+	return &DNSSOA{
+		PrimaryNS:  strings.TrimSuffix(fields[0], "."),
+		Hostmaster: strings.TrimSuffix(fields[1], "."),
+		Serial:     numbers[0],
+		Refresh:    numbers[1],
+		Retry:      numbers[2],
+		Expire:     numbers[3],
+		MinimumTTL: numbers[4],
+	}, nil
+}
+
+// GetDnsSOAObjectType returns the object type of virakcloud_dns_domain.soa.
+func GetDnsSOAObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"primary_ns":  types.StringType,
+			"hostmaster":  types.StringType,
+			"serial":      types.Int64Type,
+			"refresh":     types.Int64Type,
+			"retry":       types.Int64Type,
+			"expire":      types.Int64Type,
+			"minimum_ttl": types.Int64Type,
+		},
+	}
+}
+
+// DNSSOAObjectValue returns soa as a GetDnsSOAObjectType value, or a null
+// object when soa is nil.
+func DNSSOAObjectValue(soa *DNSSOA) types.Object {
+	objectType := GetDnsSOAObjectType()
+	if soa == nil {
+		return types.ObjectNull(objectType.AttrTypes)
+	}
+	value, _ := types.ObjectValue(objectType.AttrTypes, map[string]attr.Value{
+		"primary_ns":  types.StringValue(soa.PrimaryNS),
+		"hostmaster":  types.StringValue(soa.Hostmaster),
+		"serial":      types.Int64Value(soa.Serial),
+		"refresh":     types.Int64Value(soa.Refresh),
+		"retry":       types.Int64Value(soa.Retry),
+		"expire":      types.Int64Value(soa.Expire),
+		"minimum_ttl": types.Int64Value(soa.MinimumTTL),
+	})
+	return value
+}
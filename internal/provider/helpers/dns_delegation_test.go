@@ -0,0 +1,173 @@
+package helpers
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers LookupNS from a fixed sequence of responses, repeating
+// the last one once the sequence is exhausted.
+type fakeResolver struct {
+	responses []fakeNSResponse
+	calls     int
+}
+
+type fakeNSResponse struct {
+	hosts []string
+	err   error
+}
+
+func (f *fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	response := f.responses[min(f.calls, len(f.responses)-1)]
+	f.calls++
+	if response.err != nil {
+		return nil, response.err
+	}
+	records := make([]*net.NS, 0, len(response.hosts))
+	for _, host := range response.hosts {
+		records = append(records, &net.NS{Host: host})
+	}
+	return records, nil
+}
+
+func nxdomain(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+var testAssigned = []string{"ns1.virakcloud.com", "ns2.virakcloud.com"}
+
+func TestNormalizeNameservers(t *testing.T) {
+	got := NormalizeNameservers([]string{"NS2.VirakCloud.com.", " ns1.virakcloud.com", "", "ns2.virakcloud.com"})
+	want := []string{"ns1.virakcloud.com", "ns2.virakcloud.com"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NormalizeNameservers() = %v, want %v", got, want)
+	}
+}
+
+func TestCompareDelegation(t *testing.T) {
+	tests := []struct {
+		name      string
+		assigned  []string
+		delegated []string
+		want      string
+	}{
+		{"delegated", testAssigned, []string{"NS2.virakcloud.com.", "ns1.virakcloud.com."}, DelegationStatusDelegated},
+		{"partial", testAssigned, []string{"ns1.virakcloud.com", "ns1.registrar.example"}, DelegationStatusPartial},
+		{"subset is partial", testAssigned, []string{"ns1.virakcloud.com"}, DelegationStatusPartial},
+		{"not delegated", testAssigned, []string{"ns1.registrar.example", "ns2.registrar.example"}, DelegationStatusNotDelegated},
+		{"no lookup result", testAssigned, nil, DelegationStatusNotDelegated},
+		{"nothing assigned", nil, []string{"ns1.virakcloud.com"}, DelegationStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareDelegation(tt.assigned, tt.delegated); got != tt.want {
+				t.Errorf("CompareDelegation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSOAContent(t *testing.T) {
+	soa, err := ParseSOAContent("ns1.virakcloud.com. hostmaster.example.com. ( 2024010101 7200 3600 1209600 300 )")
+	if err != nil {
+		t.Fatalf("ParseSOAContent() error = %v", err)
+	}
+	want := DNSSOA{
+		PrimaryNS:  "ns1.virakcloud.com",
+		Hostmaster: "hostmaster.example.com",
+		Serial:     2024010101,
+		Refresh:    7200,
+		Retry:      3600,
+		Expire:     1209600,
+		MinimumTTL: 300,
+	}
+	if *soa != want {
+		t.Errorf("ParseSOAContent() = %+v, want %+v", *soa, want)
+	}
+
+	for _, content := range []string{
+		"ns1.virakcloud.com. hostmaster.example.com. 1 2 3 4",
+		"ns1.virakcloud.com. hostmaster.example.com. 1 2 3 4 five",
+	} {
+		if _, err := ParseSOAContent(content); err == nil {
+			t.Errorf("ParseSOAContent(%q) expected an error", content)
+		}
+	}
+}
+
+func TestWaitForDelegation(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []fakeNSResponse
+		wantErr   string
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			responses: []fakeNSResponse{{hosts: []string{"ns1.virakcloud.com.", "ns2.virakcloud.com."}}},
+			wantCalls: 1,
+		},
+		{
+			name: "partial then success",
+			responses: []fakeNSResponse{
+				{hosts: []string{"ns1.virakcloud.com.", "ns1.registrar.example."}},
+				{hosts: []string{"ns1.virakcloud.com.", "ns2.virakcloud.com."}},
+			},
+			wantCalls: 2,
+		},
+		{
+			name: "nxdomain then success",
+			responses: []fakeNSResponse{
+				{err: nxdomain("example.com.")},
+				{err: nxdomain("example.com.")},
+				{hosts: []string{"ns2.virakcloud.com.", "ns1.virakcloud.com."}},
+			},
+			wantCalls: 3,
+		},
+		{
+			name:      "timeout while partial",
+			responses: []fakeNSResponse{{hosts: []string{"ns1.virakcloud.com."}}},
+			wantErr:   "public nameservers are [ns1.virakcloud.com]",
+		},
+		{
+			name:      "timeout on nxdomain",
+			responses: []fakeNSResponse{{err: nxdomain("example.com.")}},
+			wantErr:   "last lookup failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeResolver{responses: tt.responses}
+			delegated, err := WaitForDelegation(context.Background(), resolver, "example.com", testAssigned, 50*time.Millisecond, time.Millisecond)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("WaitForDelegation() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WaitForDelegation() error = %v", err)
+			}
+			if !slices.Equal(delegated, testAssigned) {
+				t.Errorf("WaitForDelegation() = %v, want %v", delegated, testAssigned)
+			}
+			if resolver.calls != tt.wantCalls {
+				t.Errorf("WaitForDelegation() made %d lookups, want %d", resolver.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWaitForDelegationWithoutNameservers(t *testing.T) {
+	resolver := &fakeResolver{responses: []fakeNSResponse{{hosts: []string{"ns1.virakcloud.com."}}}}
+	if _, err := WaitForDelegation(context.Background(), resolver, "example.com", nil, time.Second, time.Millisecond); err == nil {
+		t.Fatal("WaitForDelegation() expected an error when no nameservers are assigned")
+	}
+	if resolver.calls != 0 {
+		t.Errorf("WaitForDelegation() made %d lookups, want 0", resolver.calls)
+	}
+}
@@ -0,0 +1,395 @@
+package helpers
+
+import (
+	"fmt"
+	"net/netip"
+	pathpkg "path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http/responses"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+)
+
+// DefaultDNSRecordTTL is the TTL used when a record does not set one.
+const DefaultDNSRecordTTL = 3600
+
+// DNSApexName is the relative name of a domain's apex.
+const DNSApexName = "@"
+
+// NormalizeDNSRecordName returns name relative to domain, without a trailing
+// dot, with "@" for the apex. Names are compared case-insensitively.
+func NormalizeDNSRecordName(name, domain string) string {
+	name = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	switch {
+	case name == "" || name == DNSApexName || name == domain:
+		return DNSApexName
+	case strings.HasSuffix(name, "."+domain):
+		return strings.TrimSuffix(name, "."+domain)
+	default:
+		return name
+	}
+}
+
+// NormalizeDNSContent returns content in the form used to compare records:
+// trailing dots are dropped from names and TXT quoting is removed.
+func NormalizeDNSContent(recordType, content string) string {
+	content = strings.TrimSpace(content)
+	switch strings.ToUpper(recordType) {
+	case "TXT":
+		return strings.Join(SplitTXTContent(content), "")
+	case "CNAME", "NS", "PTR", "MX", "SRV":
+		return strings.ToLower(strings.TrimSuffix(content, "."))
+	default:
+		return content
+	}
+}
+
+// SplitTXTContent returns the character strings of TXT content. Quoted
+// strings are unquoted and unescaped; unquoted content is a single string.
+func SplitTXTContent(content string) []string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return []string{content}
+	}
+
+	var parts []string
+	var current strings.Builder
+	inQuotes := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && inQuotes && i+1 < len(content):
+			i++
+			current.WriteByte(content[i])
+		case c == '"':
+			if inQuotes {
+				parts = append(parts, current.String())
+				current.Reset()
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			current.WriteByte(c)
+		}
+	}
+	if inQuotes {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// DNSRecordValue is one content value of a record set.
+type DNSRecordValue struct {
+	// APIName is the record name as returned by the API.
+	APIName   string
+	Type      string
+	ContentID string
+	Content   string
+	TTL       int
+	Status    string
+	Protected bool
+}
+
+// FindDNSRecordValues returns every content value of the record set with the
+// given name and type. Relative and fully qualified names are equivalent.
+func FindDNSRecordValues(records []responses.Record, name, recordType, domain string) []DNSRecordValue {
+	name = NormalizeDNSRecordName(name, domain)
+	var values []DNSRecordValue
+	for _, rec := range records {
+		if !strings.EqualFold(rec.Type, recordType) || NormalizeDNSRecordName(rec.Name, domain) != name {
+			continue
+		}
+		for _, c := range rec.Content {
+			values = append(values, DNSRecordValue{
+				APIName:   rec.Name,
+				Type:      rec.Type,
+				ContentID: c.ID,
+				Content:   c.ContentRaw,
+				TTL:       rec.TTL,
+				Status:    rec.Status,
+				Protected: rec.IsProtected,
+			})
+		}
+	}
+	return values
+}
+
+// IsSingleValueDNSType reports whether a record set of this type can hold
+// only one value, so any existing value collides with a new one.
+func IsSingleValueDNSType(recordType string) bool {
+	switch strings.ToUpper(recordType) {
+	case "CNAME", "SOA":
+		return true
+	default:
+		return false
+	}
+}
+
+// DNSRecordKey identifies a record value by relative name, type and
+// normalised content.
+func DNSRecordKey(name, recordType, content, domain string) string {
+	recordType = strings.ToUpper(recordType)
+	return NormalizeDNSRecordName(name, domain) + "/" + recordType + "/" + NormalizeDNSContent(recordType, content)
+}
+
+// MatchesDNSIgnore reports whether a relative record name matches one of the
+// glob patterns, e.g. "_acme-challenge*".
+func MatchesDNSIgnore(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, err := pathpkg.Match(strings.ToLower(pattern), name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDnsZoneRecordObjectType returns the object type of one record in
+// virakcloud_dns_zone_records.
+func GetDnsZoneRecordObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"record":   types.StringType,
+			"type":     types.StringType,
+			"content":  types.StringType,
+			"ttl":      types.Int64Type,
+			"priority": types.Int64Type,
+			"weight":   types.Int64Type,
+			"port":     types.Int64Type,
+			"flags":    types.Int64Type,
+			"tag":      types.StringType,
+			"license":  types.Int64Type,
+			"choicer":  types.Int64Type,
+			"match":    types.Int64Type,
+		},
+	}
+}
+
+// dnsRecordTypeFields lists the type-specific fields each record type
+// requires. Every other type-specific field is forbidden for that type.
+var dnsRecordTypeFields = map[string][]string{
+	"MX":   {"priority"},
+	"SRV":  {"priority", "weight", "port"},
+	"CAA":  {"flags", "tag"},
+	"TLSA": {"license", "choicer", "match"},
+}
+
+// CAATags are the property tags defined by RFC 8659.
+var CAATags = []string{"issue", "issuewild", "iodef"}
+
+// ValidateDNSRecordConfig checks a configured record for mistakes the API
+// would otherwise only reject at apply time: type-specific fields that are
+// missing or do not apply, malformed content and names outside the domain.
+// Unknown values are skipped. Errors are attached below base.
+func ValidateDNSRecordConfig(rec models.DnsZoneRecordModel, domain string, base path.Path, diags *diag.Diagnostics) {
+	if !rec.Record.IsNull() && !rec.Record.IsUnknown() {
+		if err := validateDNSRecordName(rec.Record.ValueString(), domain); err != nil {
+			diags.AddAttributeError(base.AtName("record"), "Invalid Record Name", err.Error())
+		}
+	}
+
+	if rec.Type.IsNull() || rec.Type.IsUnknown() {
+		return
+	}
+	recordType := strings.ToUpper(rec.Type.ValueString())
+
+	fields := []struct {
+		name  string
+		value attr.Value
+		max   int64
+	}{
+		{"priority", rec.Priority, 65535},
+		{"weight", rec.Weight, 65535},
+		{"port", rec.Port, 65535},
+		{"flags", rec.Flags, 255},
+		{"tag", rec.Tag, 0},
+		{"license", rec.License, 3},
+		{"choicer", rec.Choicer, 1},
+		{"match", rec.Match, 2},
+	}
+	required := dnsRecordTypeFields[recordType]
+	for _, field := range fields {
+		wanted := slices.Contains(required, field.name)
+		switch {
+		case wanted && field.value.IsNull():
+			diags.AddAttributeError(base.AtName(field.name), "Missing Record Field", fmt.Sprintf("%s records require %s.", recordType, field.name))
+		case !wanted && !field.value.IsNull():
+			diags.AddAttributeError(base.AtName(field.name), "Invalid Record Field", fmt.Sprintf("%s cannot be set on %s records.", field.name, recordType))
+		case field.value.IsNull() || field.value.IsUnknown():
+		case field.name == "tag":
+			if tag := rec.Tag.ValueString(); !slices.Contains(CAATags, strings.ToLower(tag)) {
+				diags.AddAttributeError(base.AtName("tag"), "Invalid Record Field", fmt.Sprintf("tag must be one of %s, got %q.", strings.Join(CAATags, ", "), tag))
+			}
+		default:
+			if n := field.value.(types.Int64).ValueInt64(); n < 0 || n > field.max {
+				diags.AddAttributeError(base.AtName(field.name), "Invalid Record Field", fmt.Sprintf("%s must be between 0 and %d, got %d.", field.name, field.max, n))
+			}
+		}
+	}
+
+	if recordType == "CNAME" && !rec.Record.IsNull() && !rec.Record.IsUnknown() && NormalizeDNSRecordName(rec.Record.ValueString(), domain) == DNSApexName {
+		diags.AddAttributeError(base.AtName("record"), "Invalid Record Name", "CNAME records cannot be created at the domain apex.")
+	}
+
+	if rec.Content.IsNull() || rec.Content.IsUnknown() {
+		return
+	}
+	if err := validateDNSContent(recordType, rec.Content.ValueString()); err != nil {
+		diags.AddAttributeError(base.AtName("content"), "Invalid Record Content", err.Error())
+	}
+}
+
+// DNSAPIRecordName returns a configured record name in the form sent to the
+// API: without the trailing dot of a fully qualified name.
+func DNSAPIRecordName(name string) string {
+	return strings.TrimSuffix(strings.TrimSpace(name), ".")
+}
+
+// DNSAPIContent returns configured content in the form sent to the API. Host
+// names lose their trailing dot, and unquoted TXT content longer than one
+// character string is split into quoted strings.
+func DNSAPIContent(recordType, content string) string {
+	switch strings.ToUpper(recordType) {
+	case "CNAME", "NS", "PTR", "MX", "SRV":
+		if content == "." {
+			return content
+		}
+		return strings.TrimSuffix(strings.TrimSpace(content), ".")
+	case "TXT":
+		if strings.HasPrefix(strings.TrimSpace(content), `"`) || len(content) <= maxTXTStringLength {
+			return content
+		}
+		var parts []string
+		for len(content) > maxTXTStringLength {
+			parts = append(parts, quoteZoneString(content[:maxTXTStringLength]))
+			content = content[maxTXTStringLength:]
+		}
+		return strings.Join(append(parts, quoteZoneString(content)), " ")
+	default:
+		return content
+	}
+}
+
+// IsValidDNSHostname reports whether name is a syntactically valid host name,
+// with or without a trailing dot. Underscore-prefixed labels are accepted for
+// service names.
+func IsValidDNSHostname(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !isValidDNSLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidDNSLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateDNSRecordName checks a record name, which may be relative ("www"),
+// "@" or fully qualified with a trailing dot. A leading "*" label is allowed.
+func validateDNSRecordName(name, domain string) error {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == DNSApexName {
+		return nil
+	}
+	if trimmed == "" {
+		return fmt.Errorf("record must not be empty; use %q for the domain apex", DNSApexName)
+	}
+
+	labels := strings.Split(strings.TrimSuffix(trimmed, "."), ".")
+	if labels[0] == "*" {
+		labels = labels[1:]
+	}
+	for _, label := range labels {
+		if !isValidDNSLabel(label) {
+			return fmt.Errorf("%q is not a valid record name", name)
+		}
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	fqdn := strings.ToLower(strings.TrimSuffix(trimmed, "."))
+	if domain != "" && strings.HasSuffix(trimmed, ".") && fqdn != domain && !strings.HasSuffix(fqdn, "."+domain) {
+		return fmt.Errorf("%q is outside the domain %s; use a name relative to the domain or a fully qualified name ending in %s.", name, domain, domain+".")
+	}
+	return nil
+}
+
+func validateDNSContent(recordType, content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return fmt.Errorf("content must not be empty")
+	}
+
+	switch recordType {
+	case "A":
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is4() {
+			return fmt.Errorf("A records need an IPv4 address, got %q", content)
+		}
+	case "AAAA":
+		if addr, err := netip.ParseAddr(content); err != nil || !addr.Is6() || addr.Is4In6() {
+			return fmt.Errorf("AAAA records need an IPv6 address, got %q", content)
+		}
+	case "CNAME", "NS", "PTR", "MX":
+		if !IsValidDNSHostname(content) {
+			return fmt.Errorf("%s records need a host name, got %q", recordType, content)
+		}
+	case "SRV":
+		if content != "." && !IsValidDNSHostname(content) {
+			return fmt.Errorf("SRV records need a target host name or \".\", got %q", content)
+		}
+	case "TXT":
+		return validateTXTContent(content)
+	}
+	return nil
+}
+
+// validateTXTContent accepts either unquoted text, which is split into
+// character strings when sent, or a sequence of quoted strings of at most 255
+// bytes each.
+func validateTXTContent(content string) error {
+	if !strings.HasPrefix(content, `"`) {
+		return nil
+	}
+
+	inQuotes := false
+	length := 0
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inQuotes && c == '\\' && i+1 < len(content):
+			i++
+			length++
+		case c == '"':
+			if inQuotes && length > maxTXTStringLength {
+				return fmt.Errorf("TXT character strings are limited to %d bytes; split the content into several quoted strings", maxTXTStringLength)
+			}
+			inQuotes = !inQuotes
+			length = 0
+		case inQuotes:
+			length++
+		case c != ' ' && c != '\t':
+			return fmt.Errorf("quoted TXT content must only contain quoted strings separated by spaces, found %q outside quotes", string(c))
+		}
+	}
+	if inQuotes {
+		return fmt.Errorf("TXT content has an unterminated quoted string")
+	}
+	return nil
+}
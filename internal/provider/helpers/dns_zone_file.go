@@ -0,0 +1,486 @@
+package helpers
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxTXTStringLength is the longest character string a TXT record can hold
+// (RFC 1035 section 3.3).
+const maxTXTStringLength = 255
+
+// ZoneFileRecord is one record value of a zone file. Name is relative to the
+// domain ("@" for the apex); type-specific fields are nil when not present.
+type ZoneFileRecord struct {
+	Name     string
+	Type     string
+	Content  string
+	TTL      int64
+	Priority *int64
+	Weight   *int64
+	Port     *int64
+	Flags    *int64
+	Tag      *string
+}
+
+// ZoneFileRecordTypes are the record types ParseZoneFile understands. SOA
+// records and apex NS records are accepted but skipped, since Virak DNS
+// manages the zone's SOA and authoritative nameservers.
+var ZoneFileRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "TXT", "SRV", "CAA", "NS"}
+
+// zoneLine is one logical line of a zone file, after comments are stripped
+// and parenthesised continuations are joined.
+type zoneLine struct {
+	number     int
+	tokens     []string
+	blankOwner bool
+}
+
+// ParseZoneFile parses an RFC 1035 zone file for domain. $ORIGIN and $TTL
+// directives, parentheses, comments, relative names and omitted owners are
+// supported; $INCLUDE and record types outside ZoneFileRecordTypes are not.
+// SOA and apex NS records are skipped, so a zone exported from another
+// provider can be imported as is; NS records delegating subdomains are kept.
+// Duplicate values are returned once.
+func ParseZoneFile(content, domain string) ([]ZoneFileRecord, error) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return nil, fmt.Errorf("domain must not be empty")
+	}
+
+	lines, err := splitZoneLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := domain + "."
+	defaultTTL := int64(DefaultDNSRecordTTL)
+	owner := ""
+	seen := map[string]bool{}
+	var records []ZoneFileRecord
+
+	for _, line := range lines {
+		tokens := line.tokens
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN takes exactly one name", line.number)
+			}
+			origin = absoluteZoneName(tokens[1], origin)
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL takes exactly one value", line.number)
+			}
+			ttl, ok := parseZoneTTL(tokens[1])
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid $TTL %q", line.number, tokens[1])
+			}
+			defaultTTL = ttl
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", line.number, tokens[0])
+		}
+
+		if !line.blankOwner {
+			owner = absoluteZoneName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record has no owner name", line.number)
+		}
+
+		ttl := defaultTTL
+		for i := 0; i < 2 && len(tokens) > 0; i++ {
+			if value, ok := parseZoneTTL(tokens[0]); ok {
+				ttl = value
+				tokens = tokens[1:]
+			} else if class := strings.ToUpper(tokens[0]); class == "IN" || class == "CH" || class == "HS" {
+				if class != "IN" {
+					return nil, fmt.Errorf("line %d: only the IN class is supported", line.number)
+				}
+				tokens = tokens[1:]
+			}
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", line.number)
+		}
+
+		fqdn := strings.TrimSuffix(owner, ".")
+		if fqdn != domain && !strings.HasSuffix(fqdn, "."+domain) {
+			return nil, fmt.Errorf("line %d: %s is outside %s", line.number, owner, domain)
+		}
+
+		rec := ZoneFileRecord{
+			Name: NormalizeDNSRecordName(fqdn, domain),
+			Type: strings.ToUpper(tokens[0]),
+			TTL:  ttl,
+		}
+		if rec.Type == "SOA" || rec.Type == "NS" && rec.Name == DNSApexName {
+			continue
+		}
+		if err := parseZoneRData(&rec, tokens[1:], origin); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.number, err)
+		}
+
+		key := DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// RenderZoneFile renders records as an RFC 1035 zone file for domain.
+// Content is taken as returned by the API; when MX, SRV or CAA content lacks
+// the leading fields, they come from the record. Records still missing a
+// field are written commented out, with "?" in place of the missing values,
+// and returned so callers can warn about them.
+func RenderZoneFile(domain string, records []ZoneFileRecord) (string, []ZoneFileRecord) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+
+	sorted := make([]ZoneFileRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		nameA, nameB := NormalizeDNSRecordName(a.Name, domain), NormalizeDNSRecordName(b.Name, domain)
+		if nameA != nameB {
+			return nameA == DNSApexName || nameB != DNSApexName && nameA < nameB
+		}
+		if zoneTypeOrder(a.Type) != zoneTypeOrder(b.Type) {
+			return zoneTypeOrder(a.Type) < zoneTypeOrder(b.Type)
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Content < b.Content
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "; Zone file for %s\n", domain)
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", domain)
+	fmt.Fprintf(&b, "$TTL %d\n", DefaultDNSRecordTTL)
+
+	var incomplete []ZoneFileRecord
+	for _, rec := range sorted {
+		rdata, missing := renderZoneRData(rec)
+		line := fmt.Sprintf("%-24s %-6d IN %-6s %s", NormalizeDNSRecordName(rec.Name, domain), rec.TTL, strings.ToUpper(rec.Type), rdata)
+		if len(missing) > 0 {
+			incomplete = append(incomplete, rec)
+			fmt.Fprintf(&b, "; incomplete, missing %s: %s\n", strings.Join(missing, ", "), line)
+			continue
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+	return b.String(), incomplete
+}
+
+// splitZoneLines tokenizes a zone file into logical lines.
+func splitZoneLines(content string) ([]zoneLine, error) {
+	var lines []zoneLine
+	var current zoneLine
+	var token strings.Builder
+	inToken, inQuotes := false, false
+	depth := 0
+	lineNumber := 1
+	atLineStart := true
+	current.number = lineNumber
+
+	flushToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+	flushLine := func() {
+		flushToken()
+		if len(current.tokens) > 0 {
+			lines = append(lines, current)
+		}
+		current = zoneLine{number: lineNumber}
+		atLineStart = true
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if inQuotes {
+			token.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(content):
+				i++
+				token.WriteByte(content[i])
+			case c == '"':
+				inQuotes = false
+			case c == '\n':
+				lineNumber++
+			}
+			continue
+		}
+
+		switch c {
+		case '\n':
+			lineNumber++
+			if depth == 0 {
+				flushLine()
+			} else {
+				flushToken()
+			}
+			continue
+		case ';':
+			for i+1 < len(content) && content[i+1] != '\n' {
+				i++
+			}
+			continue
+		case '(':
+			flushToken()
+			depth++
+		case ')':
+			flushToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced ')'", lineNumber)
+			}
+			depth--
+		case ' ', '\t', '\r':
+			if atLineStart && depth == 0 && len(current.tokens) == 0 && !inToken {
+				current.blankOwner = true
+			}
+			flushToken()
+		case '"':
+			inQuotes = true
+			inToken = true
+			token.WriteByte(c)
+		default:
+			inToken = true
+			token.WriteByte(c)
+			if c == '\\' && i+1 < len(content) {
+				i++
+				token.WriteByte(content[i])
+			}
+		}
+		atLineStart = false
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced '('", lineNumber)
+	}
+	flushLine()
+	return lines, nil
+}
+
+func parseZoneRData(rec *ZoneFileRecord, rdata []string, origin string) error {
+	want := map[string]int{"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "MX": 2, "SRV": 4, "CAA": 3}
+	if n, ok := want[rec.Type]; ok && len(rdata) != n {
+		return fmt.Errorf("%s record takes %d fields, got %d", rec.Type, n, len(rdata))
+	}
+
+	switch rec.Type {
+	case "A":
+		if ip := net.ParseIP(rdata[0]); ip == nil || ip.To4() == nil || strings.Contains(rdata[0], ":") {
+			return fmt.Errorf("invalid IPv4 address %q", rdata[0])
+		}
+		rec.Content = rdata[0]
+	case "AAAA":
+		if ip := net.ParseIP(rdata[0]); ip == nil || !strings.Contains(rdata[0], ":") {
+			return fmt.Errorf("invalid IPv6 address %q", rdata[0])
+		}
+		rec.Content = rdata[0]
+	case "CNAME", "NS":
+		rec.Content = strings.TrimSuffix(absoluteZoneName(rdata[0], origin), ".")
+	case "MX":
+		priority, err := parseZoneUint(rdata[0], 65535, "preference")
+		if err != nil {
+			return err
+		}
+		rec.Priority = &priority
+		rec.Content = strings.TrimSuffix(absoluteZoneName(rdata[1], origin), ".")
+	case "SRV":
+		var values [3]int64
+		for i, field := range []string{"priority", "weight", "port"} {
+			value, err := parseZoneUint(rdata[i], 65535, field)
+			if err != nil {
+				return err
+			}
+			values[i] = value
+		}
+		rec.Priority, rec.Weight, rec.Port = &values[0], &values[1], &values[2]
+		rec.Content = strings.TrimSuffix(absoluteZoneName(rdata[3], origin), ".")
+	case "TXT":
+		if len(rdata) == 0 {
+			return fmt.Errorf("TXT record has no content")
+		}
+		var text strings.Builder
+		for _, part := range rdata {
+			text.WriteString(strings.Join(SplitTXTContent(part), ""))
+		}
+		rec.Content = text.String()
+	case "CAA":
+		flags, err := parseZoneUint(rdata[0], 255, "flags")
+		if err != nil {
+			return err
+		}
+		tag := strings.ToLower(rdata[1])
+		rec.Flags = &flags
+		rec.Tag = &tag
+		rec.Content = strings.Join(SplitTXTContent(rdata[2]), "")
+	default:
+		return fmt.Errorf("unsupported record type %s (supported: %s)", rec.Type, strings.Join(ZoneFileRecordTypes, ", "))
+	}
+	return nil
+}
+
+// renderZoneRData returns the RDATA of rec and the names of any fields that
+// were missing from both the content and the record.
+func renderZoneRData(rec ZoneFileRecord) (string, []string) {
+	content := strings.TrimSpace(rec.Content)
+	fields := strings.Fields(content)
+
+	switch strings.ToUpper(rec.Type) {
+	case "CNAME", "NS", "PTR":
+		return zoneFQDN(content), nil
+	case "MX":
+		if len(fields) == 2 {
+			return fields[0] + " " + zoneFQDN(fields[1]), nil
+		}
+		var missing []string
+		priority := zoneField(rec.Priority, "priority", &missing)
+		return priority + " " + zoneFQDN(content), missing
+	case "SRV":
+		if len(fields) == 4 {
+			return strings.Join(fields[:3], " ") + " " + zoneFQDN(fields[3]), nil
+		}
+		var missing []string
+		priority := zoneField(rec.Priority, "priority", &missing)
+		weight := zoneField(rec.Weight, "weight", &missing)
+		port := zoneField(rec.Port, "port", &missing)
+		return strings.Join([]string{priority, weight, port, zoneFQDN(content)}, " "), missing
+	case "CAA":
+		if len(fields) >= 3 {
+			return content, nil
+		}
+		var missing []string
+		flags := zoneField(rec.Flags, "flags", &missing)
+		tag := "?"
+		if rec.Tag != nil {
+			tag = *rec.Tag
+		} else {
+			missing = append(missing, "tag")
+		}
+		return fmt.Sprintf("%s %s %s", flags, tag, quoteZoneString(strings.Trim(content, `"`))), missing
+	case "TXT", "SPF":
+		if strings.HasPrefix(content, `"`) {
+			return content, nil
+		}
+		var parts []string
+		for len(content) > maxTXTStringLength {
+			parts = append(parts, quoteZoneString(content[:maxTXTStringLength]))
+			content = content[maxTXTStringLength:]
+		}
+		parts = append(parts, quoteZoneString(content))
+		return strings.Join(parts, " "), nil
+	case "SOA":
+		if len(fields) >= 2 {
+			fields[0], fields[1] = zoneFQDN(fields[0]), zoneFQDN(fields[1])
+		}
+		return strings.Join(fields, " "), nil
+	default:
+		return content, nil
+	}
+}
+
+// absoluteZoneName qualifies name against origin, which ends in a dot.
+func absoluteZoneName(name, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + origin
+	}
+}
+
+// zoneFQDN returns an API host name, which is always absolute, with the
+// trailing dot a zone file needs.
+func zoneFQDN(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// parseZoneTTL parses a TTL in seconds or BIND notation such as 1h30m.
+func parseZoneTTL(value string) (int64, bool) {
+	if value == "" || value[0] < '0' || value[0] > '9' {
+		return 0, false
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, n >= 0
+	}
+
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var total, current int64
+	digits := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			current = current*10 + int64(c-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[c|0x20]
+		if !ok || !digits {
+			return 0, false
+		}
+		total += current * unit
+		current, digits = 0, false
+	}
+	if digits {
+		return 0, false
+	}
+	return total, true
+}
+
+func parseZoneUint(value string, max int64, field string) (int64, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 || n > max {
+		return 0, fmt.Errorf("invalid %s %q", field, value)
+	}
+	return n, nil
+}
+
+func quoteZoneString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// zoneField renders an optional numeric field, recording its name in missing
+// and rendering "?" when it is unset.
+func zoneField(value *int64, name string, missing *[]string) string {
+	if value == nil {
+		*missing = append(*missing, name)
+		return "?"
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+// zoneTypeOrder puts SOA and then NS records first within a name, as zone
+// files do.
+func zoneTypeOrder(recordType string) int {
+	switch strings.ToUpper(recordType) {
+	case "SOA":
+		return 0
+	case "NS":
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,183 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func stringPtr(v string) *string { return &v }
+
+func TestParseZoneFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []ZoneFileRecord
+	}{
+		{
+			name: "origin and ttl directives",
+			content: `$ORIGIN example.com.
+$TTL 1h
+www          IN A     192.0.2.1
+$ORIGIN sub.example.com.
+$TTL 300
+api   600    IN AAAA  2001:db8::1
+@            IN CNAME target
+`,
+			want: []ZoneFileRecord{
+				{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+				{Name: "api.sub", Type: "AAAA", Content: "2001:db8::1", TTL: 600},
+				{Name: "sub", Type: "CNAME", Content: "target.sub.example.com", TTL: 300},
+			},
+		},
+		{
+			name: "parenthesised soa and apex ns are skipped",
+			content: `@ 3600 IN SOA ns1.other.example. hostmaster.example.com. (
+        2024010101 ; serial
+        7200       ; refresh
+        3600       ; retry
+        1209600    ; expire
+        300 )      ; minimum
+@    IN NS ns1.other.example.
+     IN NS ns2.other.example.
+dev  IN NS ns1.dev-dns.example.
+`,
+			want: []ZoneFileRecord{
+				{Name: "dev", Type: "NS", Content: "ns1.dev-dns.example", TTL: DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "mx srv and caa fields",
+			content: `@     IN MX  10 mail
+_sip._tcp IN SRV 10 60 5060 sip.example.com.
+@     IN CAA 0 ISSUE "letsencrypt.org"
+`,
+			want: []ZoneFileRecord{
+				{Name: "@", Type: "MX", Content: "mail.example.com", TTL: DefaultDNSRecordTTL, Priority: int64Ptr(10)},
+				{Name: "_sip._tcp", Type: "SRV", Content: "sip.example.com", TTL: DefaultDNSRecordTTL, Priority: int64Ptr(10), Weight: int64Ptr(60), Port: int64Ptr(5060)},
+				{Name: "@", Type: "CAA", Content: "letsencrypt.org", TTL: DefaultDNSRecordTTL, Flags: int64Ptr(0), Tag: stringPtr("issue")},
+			},
+		},
+		{
+			name:    "multi-string txt",
+			content: `@ IN TXT ( "v=spf1 include:_spf.example.net " "-all" )` + "\n",
+			want: []ZoneFileRecord{
+				{Name: "@", Type: "TXT", Content: "v=spf1 include:_spf.example.net -all", TTL: DefaultDNSRecordTTL},
+			},
+		},
+		{
+			name: "owner inheritance and duplicates",
+			content: `www IN A 192.0.2.1
+    IN A 192.0.2.2
+    IN A 192.0.2.1
+mail.example.com. IN A 192.0.2.3
+`,
+			want: []ZoneFileRecord{
+				{Name: "www", Type: "A", Content: "192.0.2.1", TTL: DefaultDNSRecordTTL},
+				{Name: "www", Type: "A", Content: "192.0.2.2", TTL: DefaultDNSRecordTTL},
+				{Name: "mail", Type: "A", Content: "192.0.2.3", TTL: DefaultDNSRecordTTL},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseZoneFile(tt.content, "example.com")
+			if err != nil {
+				t.Fatalf("ParseZoneFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseZoneFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"mx without preference", "@ IN MX mail\n", "MX record takes 2 fields"},
+		{"srv missing port", "_sip._tcp IN SRV 10 60 sip\n", "SRV record takes 4 fields"},
+		{"caa flags out of range", `@ IN CAA 256 issue "ca.example"` + "\n", "invalid flags"},
+		{"owner outside domain", "www.example.org. IN A 192.0.2.1\n", "outside example.com"},
+		{"no owner", " IN A 192.0.2.1\n", "no owner name"},
+		{"unbalanced parenthesis", "@ IN TXT ( \"a\"\n", "unbalanced '('"},
+		{"include", "$INCLUDE other.zone\n", "not supported"},
+		{"unsupported type", "@ IN PTR host\n", "unsupported record type PTR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseZoneFile(tt.content, "example.com")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseZoneFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderZoneFileRoundTrip(t *testing.T) {
+	records := []ZoneFileRecord{
+		{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 300},
+		{Name: "@", Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: int64Ptr(10)},
+		{Name: "_sip._tcp", Type: "SRV", Content: "sip.example.com", TTL: 3600, Priority: int64Ptr(10), Weight: int64Ptr(60), Port: int64Ptr(5060)},
+		{Name: "@", Type: "CAA", Content: "letsencrypt.org", TTL: 3600, Flags: int64Ptr(0), Tag: stringPtr("issue")},
+		{Name: "@", Type: "TXT", Content: strings.Repeat("a", 300), TTL: 3600},
+		{Name: "dev", Type: "NS", Content: "ns1.dev-dns.example", TTL: 3600},
+	}
+
+	content, incomplete := RenderZoneFile("example.com", records)
+	if len(incomplete) != 0 {
+		t.Fatalf("RenderZoneFile() incomplete = %+v, want none", incomplete)
+	}
+	got, err := ParseZoneFile(content, "example.com")
+	if err != nil {
+		t.Fatalf("ParseZoneFile() error = %v\n%s", err, content)
+	}
+
+	byKey := func(recs []ZoneFileRecord) map[string]ZoneFileRecord {
+		m := make(map[string]ZoneFileRecord, len(recs))
+		for _, rec := range recs {
+			m[rec.Name+"/"+rec.Type] = rec
+		}
+		return m
+	}
+	if !reflect.DeepEqual(byKey(got), byKey(records)) {
+		t.Errorf("round trip = %+v, want %+v\n%s", got, records, content)
+	}
+}
+
+func TestRenderZoneFileIncomplete(t *testing.T) {
+	records := []ZoneFileRecord{
+		{Name: "@", Type: "MX", Content: "mail.example.com", TTL: 3600},
+		{Name: "_sip._tcp", Type: "SRV", Content: "sip.example.com", TTL: 3600, Priority: int64Ptr(10)},
+		{Name: "@", Type: "CAA", Content: "letsencrypt.org", TTL: 3600},
+		{Name: "@", Type: "MX", Content: "20 backup.example.com", TTL: 3600},
+	}
+
+	content, incomplete := RenderZoneFile("example.com", records)
+	if len(incomplete) != 3 {
+		t.Errorf("RenderZoneFile() returned %d incomplete records, want 3", len(incomplete))
+	}
+	for _, want := range []string{
+		"; incomplete, missing priority: @",
+		"; incomplete, missing weight, port: _sip._tcp",
+		"; incomplete, missing flags, tag: @",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("RenderZoneFile() output does not contain %q:\n%s", want, content)
+		}
+	}
+
+	got, err := ParseZoneFile(content, "example.com")
+	if err != nil {
+		t.Fatalf("ParseZoneFile() error = %v\n%s", err, content)
+	}
+	want := []ZoneFileRecord{{Name: "@", Type: "MX", Content: "backup.example.com", TTL: 3600, Priority: int64Ptr(20)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseZoneFile(rendered) = %+v, want %+v", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 )
@@ -21,3 +22,15 @@ func HandleValidationError(diags *diag.Diagnostics, summary string, detail strin
 		detail,
 	)
 }
+
+// IsNotFoundError reports whether an API error says the requested object does
+// not exist. The client does not expose status codes, so the error text is
+// matched the same way resource_volume.go does.
+func IsNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "404") || strings.Contains(msg, "not found") ||
+		strings.Contains(msg, "does not exist")
+}
@@ -0,0 +1,455 @@
+package helpers
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+)
+
+// FirewallRule is a version-independent view of an IPv4 or IPv6 firewall rule.
+// Optional numeric fields are nil when the rule does not set them.
+type FirewallRule struct {
+	ID            string
+	IPVersion     string
+	TrafficType   string
+	Protocol      string
+	IPSource      string
+	IPDestination string
+	StartPort     *int64
+	EndPort       *int64
+	ICMPCode      *int64
+	ICMPType      *int64
+	PublicIPID    string
+	Status        string
+	CreatedAt     int64
+}
+
+// Key identifies a rule by everything except its ID and status, so that a
+// declared rule can be matched against the rules that exist on a network.
+func (r FirewallRule) Key() string {
+	ports, icmp := "-", "-"
+	if strings.EqualFold(r.Protocol, FirewallProtocolICMP) {
+		icmp = fmt.Sprintf("%s/%s", formatOptionalInt(r.ICMPType), formatOptionalInt(r.ICMPCode))
+	} else {
+		ports = fmt.Sprintf("%s-%s", formatOptionalInt(r.StartPort), formatOptionalInt(r.EndPort))
+	}
+	return strings.Join([]string{
+		strings.ToLower(r.IPVersion),
+		strings.ToLower(r.TrafficType),
+		strings.ToUpper(r.Protocol),
+		NormalizeCIDR(r.IPSource),
+		NormalizeCIDR(r.IPDestination),
+		ports,
+		icmp,
+		r.PublicIPID,
+	}, "|")
+}
+
+// String renders a rule for diagnostics.
+func (r FirewallRule) String() string {
+	desc := fmt.Sprintf("%s %s %s %s -> %s", r.IPVersion, r.TrafficType, r.Protocol, r.IPSource, r.IPDestination)
+	if strings.EqualFold(r.Protocol, FirewallProtocolICMP) {
+		desc += fmt.Sprintf(" type %s code %s", formatOptionalInt(r.ICMPType), formatOptionalInt(r.ICMPCode))
+	} else if r.StartPort != nil || r.EndPort != nil {
+		desc += fmt.Sprintf(" ports %s-%s", formatOptionalInt(r.StartPort), formatOptionalInt(r.EndPort))
+	}
+	if r.ID != "" {
+		desc += fmt.Sprintf(" (%s)", r.ID)
+	}
+	return desc
+}
+
+// CanonicalTrafficType maps API and user spellings to "Ingress" or "Egress".
+func CanonicalTrafficType(value string) string {
+	switch strings.ToLower(value) {
+	case strings.ToLower(FirewallTrafficIngress):
+		return FirewallTrafficIngress
+	case strings.ToLower(FirewallTrafficEgress):
+		return FirewallTrafficEgress
+	}
+	return value
+}
+
+// ListFirewallRules returns all IPv4 and IPv6 firewall rules on a network.
+func ListFirewallRules(client *http.Client, zoneID, networkID string) ([]FirewallRule, error) {
+	rules := make([]FirewallRule, 0)
+
+	v4Resp, err := client.ListIPv4FirewallRules(zoneID, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list IPv4 firewall rules for network %s: %w", networkID, err)
+	}
+	for _, rule := range v4Resp.Data {
+		publicIPID := ""
+		if rule.NetworkPublicIPID != nil {
+			publicIPID = *rule.NetworkPublicIPID
+		}
+		rules = append(rules, FirewallRule{
+			ID:            rule.ID,
+			IPVersion:     FirewallIPv4,
+			TrafficType:   CanonicalTrafficType(rule.TrafficType),
+			Protocol:      strings.ToUpper(rule.Protocol),
+			IPSource:      rule.IPSource,
+			IPDestination: rule.IPDestination,
+			StartPort:     parseOptionalPort(rule.PortStart),
+			EndPort:       parseOptionalPort(rule.PortEnd),
+			ICMPCode:      optionalInt(rule.ICMPCode),
+			ICMPType:      optionalInt(rule.ICMPType),
+			PublicIPID:    publicIPID,
+			Status:        rule.Status,
+			CreatedAt:     rule.CreatedAt,
+		})
+	}
+
+	v6Resp, err := client.ListIPv6FirewallRules(zoneID, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list IPv6 firewall rules for network %s: %w", networkID, err)
+	}
+	for _, rule := range v6Resp.Data {
+		rules = append(rules, FirewallRule{
+			ID:            rule.ID,
+			IPVersion:     FirewallIPv6,
+			TrafficType:   CanonicalTrafficType(rule.TrafficType),
+			Protocol:      strings.ToUpper(rule.Protocol),
+			IPSource:      rule.IPSource,
+			IPDestination: rule.IPDestination,
+			StartPort:     parseOptionalPort(rule.PortStart),
+			EndPort:       parseOptionalPort(rule.PortEnd),
+			ICMPCode:      optionalInt(rule.ICMPCode),
+			ICMPType:      optionalInt(rule.ICMPType),
+			Status:        rule.Status,
+			CreatedAt:     rule.CreatedAt,
+		})
+	}
+
+	return rules, nil
+}
+
+// CreateFirewallRule creates a rule and returns its ID. The create endpoints
+// do not return the new ID, so it is found by diffing the rule list taken
+// before and after the call.
+func CreateFirewallRule(client *http.Client, zoneID, networkID string, rule FirewallRule) (string, error) {
+	before, err := ListFirewallRules(client, zoneID, networkID)
+	if err != nil {
+		return "", err
+	}
+	existingIDs := make(map[string]struct{}, len(before))
+	for _, existing := range before {
+		existingIDs[existing.ID] = struct{}{}
+	}
+
+	body := map[string]interface{}{
+		"traffic_type":   rule.TrafficType,
+		"protocol_type":  rule.Protocol,
+		"ip_source":      NormalizeCIDR(rule.IPSource),
+		"ip_destination": NormalizeCIDR(rule.IPDestination),
+	}
+	if strings.EqualFold(rule.Protocol, FirewallProtocolICMP) {
+		if rule.ICMPCode != nil {
+			body["icmp_code"] = int(*rule.ICMPCode)
+		}
+		if rule.ICMPType != nil {
+			body["icmp_type"] = int(*rule.ICMPType)
+		}
+	} else {
+		if rule.StartPort != nil {
+			body["port_start"] = int(*rule.StartPort)
+		}
+		if rule.EndPort != nil {
+			body["port_end"] = int(*rule.EndPort)
+		}
+	}
+
+	switch rule.IPVersion {
+	case FirewallIPv4:
+		if rule.PublicIPID != "" {
+			body["public_ip_id"] = rule.PublicIPID
+		}
+		createResp, err := client.CreateIPv4FirewallRule(zoneID, networkID, body)
+		if err != nil {
+			return "", fmt.Errorf("failed to create IPv4 firewall rule %s: %w", rule, err)
+		}
+		if !createResp.Data.Success {
+			return "", fmt.Errorf("API returned failure for IPv4 firewall rule creation %s", rule)
+		}
+	case FirewallIPv6:
+		createResp, err := client.CreateIPv6FirewallRule(zoneID, networkID, body)
+		if err != nil {
+			return "", fmt.Errorf("failed to create IPv6 firewall rule %s: %w", rule, err)
+		}
+		if !createResp.Data.Success {
+			return "", fmt.Errorf("API returned failure for IPv6 firewall rule creation %s", rule)
+		}
+	default:
+		return "", fmt.Errorf("IP version must be '%s' or '%s', got '%s'", FirewallIPv4, FirewallIPv6, rule.IPVersion)
+	}
+
+	after, err := ListFirewallRules(client, zoneID, networkID)
+	if err != nil {
+		return "", err
+	}
+	// Only accept a single new rule matching the request; anything else means
+	// the rule cannot be identified without guessing.
+	var matches []string
+	for _, created := range after {
+		if _, exists := existingIDs[created.ID]; exists {
+			continue
+		}
+		if created.Key() == rule.Key() {
+			matches = append(matches, created.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("firewall rule %s was created but no matching rule was found on network %s; import it or remove it manually", rule, networkID)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("firewall rule %s was created but %d new matching rules were found on network %s (%s); the new rule cannot be identified", rule, len(matches), networkID, strings.Join(matches, ", "))
+	}
+}
+
+// DeleteFirewallRule deletes a rule using the endpoint for its IP version.
+func DeleteFirewallRule(client *http.Client, zoneID, networkID string, rule FirewallRule) error {
+	if rule.IPVersion == FirewallIPv6 {
+		deleteResp, err := client.DeleteIPv6FirewallRule(zoneID, networkID, rule.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete IPv6 firewall rule %s: %w", rule, err)
+		}
+		if !deleteResp.Data.Success {
+			return fmt.Errorf("API returned failure for IPv6 firewall rule deletion %s", rule)
+		}
+		return nil
+	}
+
+	deleteResp, err := client.DeleteIPv4FirewallRule(zoneID, networkID, rule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete IPv4 firewall rule %s: %w", rule, err)
+	}
+	if !deleteResp.Data.Success {
+		return fmt.Errorf("API returned failure for IPv4 firewall rule deletion %s", rule)
+	}
+	return nil
+}
+
+// DiffFirewallRules matches desired rules against existing ones by Key and
+// returns the rules that must be created and the existing rules that must be
+// deleted. Duplicate existing rules beyond the desired count are deleted.
+// Callers create toCreate before deleting toDelete, so that traffic allowed
+// by both the old and new rules is never interrupted.
+func DiffFirewallRules(desired, existing []FirewallRule) (toCreate, toDelete []FirewallRule) {
+	available := make(map[string][]FirewallRule)
+	for _, rule := range existing {
+		available[rule.Key()] = append(available[rule.Key()], rule)
+	}
+
+	for _, rule := range desired {
+		key := rule.Key()
+		if matches := available[key]; len(matches) > 0 {
+			available[key] = matches[1:]
+			continue
+		}
+		toCreate = append(toCreate, rule)
+	}
+
+	for _, rule := range existing {
+		for _, leftover := range available[rule.Key()] {
+			if leftover.ID == rule.ID {
+				toDelete = append(toDelete, rule)
+				break
+			}
+		}
+	}
+
+	return toCreate, toDelete
+}
+
+func parseOptionalPort(value *string) *int64 {
+	if value == nil || *value == "" {
+		return nil
+	}
+	port, err := strconv.ParseInt(*value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &port
+}
+
+func optionalInt(value *int) *int64 {
+	if value == nil {
+		return nil
+	}
+	v := int64(*value)
+	return &v
+}
+
+func formatOptionalInt(value *int64) string {
+	if value == nil {
+		return "*"
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func GetFirewallRuleObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"ip_version":     types.StringType,
+			"traffic_type":   types.StringType,
+			"protocol":       types.StringType,
+			"ip_source":      types.StringType,
+			"ip_destination": types.StringType,
+			"start_port":     types.Int64Type,
+			"end_port":       types.Int64Type,
+			"icmp_code":      types.Int64Type,
+			"icmp_type":      types.Int64Type,
+			"public_ip_id":   types.StringType,
+		},
+	}
+}
+
+// GetSecurityGroupSpecObjectType returns the object type of a security
+// group's spec, as consumed by security group bindings.
+func GetSecurityGroupSpecObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":      types.StringType,
+			"version": types.Int64Type,
+			"rules":   types.SetType{ElemType: GetFirewallRuleObjectType()},
+		},
+	}
+}
+
+// FirewallRulesFromModels converts configured rule blocks into FirewallRules.
+func FirewallRulesFromModels(ruleModels []models.FirewallRulesetRuleModel) []FirewallRule {
+	rules := make([]FirewallRule, 0, len(ruleModels))
+	for _, m := range ruleModels {
+		rules = append(rules, FirewallRule{
+			IPVersion:     strings.ToLower(m.IPVersion.ValueString()),
+			TrafficType:   CanonicalTrafficType(m.TrafficType.ValueString()),
+			Protocol:      strings.ToUpper(m.Protocol.ValueString()),
+			IPSource:      m.IPSource.ValueString(),
+			IPDestination: m.IPDestination.ValueString(),
+			StartPort:     m.StartPort.ValueInt64Pointer(),
+			EndPort:       m.EndPort.ValueInt64Pointer(),
+			ICMPCode:      m.ICMPCode.ValueInt64Pointer(),
+			ICMPType:      m.ICMPType.ValueInt64Pointer(),
+			PublicIPID:    m.PublicIPID.ValueString(),
+		})
+	}
+	return rules
+}
+
+// CreateFirewallRulesSet converts FirewallRules into a set of rule objects.
+func CreateFirewallRulesSet(rules []FirewallRule, diags *diag.Diagnostics) types.Set {
+	objects := make([]attr.Value, 0, len(rules))
+	for _, rule := range rules {
+		publicIPID := types.StringNull()
+		if rule.PublicIPID != "" {
+			publicIPID = types.StringValue(rule.PublicIPID)
+		}
+		obj, objDiags := types.ObjectValue(
+			GetFirewallRuleObjectType().AttrTypes,
+			map[string]attr.Value{
+				"ip_version":     types.StringValue(rule.IPVersion),
+				"traffic_type":   types.StringValue(rule.TrafficType),
+				"protocol":       types.StringValue(rule.Protocol),
+				"ip_source":      types.StringValue(rule.IPSource),
+				"ip_destination": types.StringValue(rule.IPDestination),
+				"start_port":     types.Int64PointerValue(rule.StartPort),
+				"end_port":       types.Int64PointerValue(rule.EndPort),
+				"icmp_code":      types.Int64PointerValue(rule.ICMPCode),
+				"icmp_type":      types.Int64PointerValue(rule.ICMPType),
+				"public_ip_id":   publicIPID,
+			},
+		)
+		diags.Append(objDiags...)
+		objects = append(objects, obj)
+	}
+
+	set, setDiags := types.SetValue(GetFirewallRuleObjectType(), objects)
+	diags.Append(setDiags...)
+	return set
+}
+
+// NormalizeCIDR returns the canonical CIDR form of an address or prefix: host
+// addresses gain a /32 or /128 suffix and host bits are cleared. Values that
+// do not parse are returned unchanged.
+func NormalizeCIDR(value string) string {
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		return prefix.Masked().String()
+	}
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()).String()
+	}
+	return value
+}
+
+// EquivalentCIDR reports whether two address or CIDR strings describe the same
+// network once normalised.
+func EquivalentCIDR(a, b string) bool {
+	return NormalizeCIDR(a) == NormalizeCIDR(b)
+}
+
+// ValidateFirewallRuleConfig checks a configured rule for mistakes the API
+// would otherwise only reject at apply time. Unknown values are skipped.
+// Errors are attached below base, e.g. path.Empty() for a top-level rule.
+func ValidateFirewallRuleConfig(rule models.FirewallRulesetRuleModel, base path.Path, diags *diag.Diagnostics) {
+	ipVersion := ""
+	if !rule.IPVersion.IsNull() && !rule.IPVersion.IsUnknown() {
+		ipVersion = rule.IPVersion.ValueString()
+	}
+
+	addresses := []struct {
+		name  string
+		value types.String
+	}{{"ip_source", rule.IPSource}, {"ip_destination", rule.IPDestination}}
+	for _, address := range addresses {
+		name, value := address.name, address.value
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(NormalizeCIDR(value.ValueString()))
+		if err != nil {
+			diags.AddAttributeError(base.AtName(name), "Invalid CIDR", fmt.Sprintf("'%s' is not a valid IP address or CIDR block.", value.ValueString()))
+			continue
+		}
+		if ipVersion == FirewallIPv4 && !prefix.Addr().Is4() {
+			diags.AddAttributeError(base.AtName(name), "IP Version Mismatch", fmt.Sprintf("'%s' is not an IPv4 address or CIDR, but ip_version is '%s'.", value.ValueString(), ipVersion))
+		}
+		if ipVersion == FirewallIPv6 && !prefix.Addr().Is6() {
+			diags.AddAttributeError(base.AtName(name), "IP Version Mismatch", fmt.Sprintf("'%s' is not an IPv6 address or CIDR, but ip_version is '%s'.", value.ValueString(), ipVersion))
+		}
+	}
+
+	if ipVersion == FirewallIPv6 && !rule.PublicIPID.IsNull() {
+		diags.AddAttributeError(base.AtName("public_ip_id"), "Invalid Configuration", "public_ip_id can only be set on ipv4 rules.")
+	}
+
+	if !rule.StartPort.IsNull() && !rule.StartPort.IsUnknown() && !rule.EndPort.IsNull() && !rule.EndPort.IsUnknown() &&
+		rule.StartPort.ValueInt64() > rule.EndPort.ValueInt64() {
+		diags.AddAttributeError(base.AtName("start_port"), "Invalid Port Range", fmt.Sprintf("start_port (%d) must be less than or equal to end_port (%d).", rule.StartPort.ValueInt64(), rule.EndPort.ValueInt64()))
+	}
+	if rule.StartPort.IsNull() && !rule.EndPort.IsNull() {
+		diags.AddAttributeError(base.AtName("end_port"), "Invalid Port Range", "end_port requires start_port to be set.")
+	}
+
+	if rule.Protocol.IsNull() || rule.Protocol.IsUnknown() {
+		return
+	}
+	if rule.Protocol.ValueString() == FirewallProtocolICMP {
+		if !rule.StartPort.IsNull() || !rule.EndPort.IsNull() {
+			diags.AddAttributeError(base.AtName("start_port"), "Invalid Configuration", "start_port and end_port can only be set for TCP or UDP rules.")
+		}
+		return
+	}
+	if !rule.ICMPType.IsNull() || !rule.ICMPCode.IsNull() {
+		diags.AddAttributeError(base.AtName("icmp_type"), "Invalid Configuration", "icmp_type and icmp_code can only be set for ICMP rules.")
+	}
+}
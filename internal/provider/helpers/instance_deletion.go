@@ -1,12 +1,98 @@
 package helpers
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
 )
 
+// DeleteStrategy controls the steps taken when an instance is destroyed.
+// The zero-configuration defaults match the provider's original behaviour.
+type DeleteStrategy struct {
+	BootBeforeDelete bool
+	Volumes          string
+	StopBeforeDelete bool
+	WaitForExpunge   bool
+}
+
+func DefaultDeleteStrategy() DeleteStrategy {
+	return DeleteStrategy{
+		BootBeforeDelete: true,
+		Volumes:          DeleteVolumesDetach,
+		WaitForExpunge:   true,
+	}
+}
+
+// NewDeleteStrategy reads the delete_strategy attribute, using defaults for
+// the block itself and for any unset field.
+func NewDeleteStrategy(ctx context.Context, obj types.Object, diags *diag.Diagnostics) DeleteStrategy {
+	strategy := DefaultDeleteStrategy()
+	if obj.IsNull() || obj.IsUnknown() {
+		return strategy
+	}
+
+	var data models.InstanceDeleteStrategyModel
+	diags.Append(obj.As(ctx, &data, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return strategy
+	}
+
+	if !data.BootBeforeDelete.IsNull() && !data.BootBeforeDelete.IsUnknown() {
+		strategy.BootBeforeDelete = data.BootBeforeDelete.ValueBool()
+	}
+	if !data.Volumes.IsNull() && !data.Volumes.IsUnknown() && data.Volumes.ValueString() != "" {
+		strategy.Volumes = data.Volumes.ValueString()
+	}
+	if !data.StopBeforeDelete.IsNull() && !data.StopBeforeDelete.IsUnknown() {
+		strategy.StopBeforeDelete = data.StopBeforeDelete.ValueBool()
+	}
+	if !data.WaitForExpunge.IsNull() && !data.WaitForExpunge.IsUnknown() {
+		strategy.WaitForExpunge = data.WaitForExpunge.ValueBool()
+	}
+	return strategy
+}
+
+// DeleteStepReport collects the steps performed while destroying an instance.
+// Every step is logged; steps that differ from the default behaviour or that
+// failed are also surfaced as a single diagnostic.
+type DeleteStepReport struct {
+	steps   []string
+	notable bool
+}
+
+// Add records a step of the default delete behaviour.
+func (r *DeleteStepReport) Add(format string, args ...interface{}) {
+	step := fmt.Sprintf(format, args...)
+	LogInfo("Instance delete: %s", step)
+	r.steps = append(r.steps, step)
+}
+
+// AddNotable records a non-default or failed step, which makes the report
+// worth surfacing.
+func (r *DeleteStepReport) AddNotable(format string, args ...interface{}) {
+	r.Add(format, args...)
+	r.notable = true
+}
+
+// Notable reports whether any non-default or failed step was recorded.
+func (r *DeleteStepReport) Notable() bool {
+	return r.notable
+}
+
+func (r *DeleteStepReport) String() string {
+	lines := make([]string, 0, len(r.steps))
+	for i, step := range r.steps {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, step))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // DetachAllVolumes detaches all volumes from an instance before deletion
 func DetachAllVolumes(client *http.Client, zoneID, instanceID string, diags *diag.Diagnostics) {
 	readResp, err := GetInstanceDetails(client, zoneID, instanceID)
@@ -40,6 +126,42 @@ func DetachAllVolumes(client *http.Client, zoneID, instanceID string, diags *dia
 	}
 }
 
+// DeleteAllVolumes detaches every volume attached to an instance and deletes it.
+// It returns the IDs of the volumes that were deleted and of those that were
+// not, because deleting them failed or an earlier volume failed first.
+func DeleteAllVolumes(client *http.Client, zoneID, instanceID string, diags *diag.Diagnostics) (deleted, failed []string) {
+	readResp, err := GetInstanceDetails(client, zoneID, instanceID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read instance before volume deletion, got error: %s", err))
+		return nil, nil
+	}
+
+	attachedVolumeIDs := GetAttachedVolumeIDs(readResp)
+
+	DetachAllVolumes(client, zoneID, instanceID, diags)
+	if diags.HasError() {
+		return nil, attachedVolumeIDs
+	}
+
+	deleted = make([]string, 0, len(attachedVolumeIDs))
+	for i, volID := range attachedVolumeIDs {
+		err := WaitForVolumeStatus(client, zoneID, volID, VolumeStatusAllocated, MaxVolumeAttachmentRetries, DefaultVolumePollInterval)
+		if err != nil {
+			diags.AddError("Volume Delete Blocked", fmt.Sprintf("Volume '%s' did not return to ALLOCATED status after detaching from instance '%s'. Error: %s", volID, instanceID, err))
+			return deleted, attachedVolumeIDs[i:]
+		}
+
+		_, err = client.DeleteInstanceVolume(zoneID, volID)
+		if err != nil {
+			diags.AddError("Volume Delete Failed", fmt.Sprintf("Failed to delete volume '%s' attached to instance '%s': %s", volID, instanceID, err))
+			return deleted, attachedVolumeIDs[i:]
+		}
+		deleted = append(deleted, volID)
+	}
+
+	return deleted, nil
+}
+
 // DisconnectAllNetworks disconnects all networks from an instance before deletion
 func DisconnectAllNetworks(client *http.Client, zoneID, instanceID string, diags *diag.Diagnostics) {
 	networks, err := GetInstanceNetworks(client, zoneID, instanceID)
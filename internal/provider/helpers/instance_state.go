@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -38,23 +39,19 @@ func EnsureInstanceRunning(client *http.Client, zoneID, instanceID string, diags
 	return nil
 }
 
-func EnsureInstanceStopped(client *http.Client, zoneID, instanceID string, diags *diag.Diagnostics) error {
+func EnsureInstanceStopped(client *http.Client, zoneID, instanceID string, policy StopPolicy, diags *diag.Diagnostics) error {
 	readResp, err := client.ShowInstance(zoneID, instanceID)
 	if err != nil {
 		diags.AddError("Client Error", fmt.Sprintf("Unable to read instance before ensuring stopped state, got error: %s", err))
 		return err
 	}
 
-	instanceStatus := readResp.Data.Status
-	if IsInstanceRunning(instanceStatus) {
-		_, err = client.StopInstance(zoneID, instanceID, false)
-		if err != nil {
-			diags.AddError("Client Error", fmt.Sprintf("Unable to stop instance, got error: %s", err))
-			return err
-		}
+	if IsInstanceRunning(readResp.Data.Status) {
+		_, err = StopInstanceWithPolicy(client, zoneID, instanceID, policy, diags)
+		return err
 	}
 
-	status, err := WaitForInstanceStatus(client, zoneID, instanceID, []string{InstanceStatusStopped, InstanceStatusSTOPPED, InstanceStatusDown}, MaxInstanceStatusRetries, DefaultPollInterval)
+	status, err := WaitForInstanceStatus(client, zoneID, instanceID, stoppedStatuses(), MaxInstanceStatusRetries, DefaultPollInterval)
 	if err != nil {
 		diags.AddError("Instance Not Stopped", fmt.Sprintf("Instance '%s' did not reach stopped state. Current status: %s. Error: %s", instanceID, status, err))
 		return err
@@ -63,33 +60,99 @@ func EnsureInstanceStopped(client *http.Client, zoneID, instanceID string, diags
 	return nil
 }
 
+// StopPolicy controls how a running instance is stopped: gracefully, forcefully,
+// or gracefully with a forced stop once GracefulTimeout has elapsed.
+type StopPolicy struct {
+	Mode            string
+	GracefulTimeout time.Duration
+}
+
+func DefaultStopPolicy() StopPolicy {
+	return StopPolicy{Mode: StopModeGraceful, GracefulTimeout: DefaultGracefulStopTimeout}
+}
+
+// NewStopPolicy builds a StopPolicy from the stop_mode and graceful_stop_timeout
+// attributes, falling back to the defaults for null or unknown values.
+func NewStopPolicy(mode types.String, gracefulTimeoutSeconds types.Int64) StopPolicy {
+	policy := DefaultStopPolicy()
+	if !mode.IsNull() && !mode.IsUnknown() && mode.ValueString() != "" {
+		policy.Mode = mode.ValueString()
+	}
+	if !gracefulTimeoutSeconds.IsNull() && !gracefulTimeoutSeconds.IsUnknown() && gracefulTimeoutSeconds.ValueInt64() > 0 {
+		policy.GracefulTimeout = time.Duration(gracefulTimeoutSeconds.ValueInt64()) * time.Second
+	}
+	return policy
+}
+
+func (p StopPolicy) gracefulRetries() int {
+	retries := int(p.GracefulTimeout / DefaultPollInterval)
+	if p.GracefulTimeout%DefaultPollInterval != 0 {
+		retries++
+	}
+	if retries < 1 {
+		retries = 1
+	}
+	return retries
+}
+
+// StopInstanceWithPolicy stops a running instance according to policy and waits
+// until it reports a stopped status.
+func StopInstanceWithPolicy(client *http.Client, zoneID, instanceID string, policy StopPolicy, diags *diag.Diagnostics) (string, error) {
+	forced := policy.Mode == StopModeForce
+	if _, err := client.StopInstance(zoneID, instanceID, forced); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to stop instance, got error: %s", err))
+		return "", err
+	}
+
+	if forced {
+		status, err := WaitForInstanceStatus(client, zoneID, instanceID, stoppedStatuses(), MaxInstanceStatusRetries, DefaultPollInterval)
+		if err != nil {
+			diags.AddError("Instance Not Stopped", fmt.Sprintf("Instance '%s' did not reach stopped state after a forced stop. Current status: %s. Error: %s", instanceID, status, err))
+			return status, err
+		}
+		return status, nil
+	}
+
+	status, err := WaitForInstanceStatus(client, zoneID, instanceID, stoppedStatuses(), policy.gracefulRetries(), DefaultPollInterval)
+	if err == nil {
+		return status, nil
+	}
+
+	if policy.Mode != StopModeGracefulThenForce {
+		diags.AddError("Instance Not Stopped", fmt.Sprintf("Instance '%s' did not stop gracefully within %s. Current status: %s. Error: %s. Set stop_mode to '%s' or '%s' to force the stop.", instanceID, policy.GracefulTimeout, status, err, StopModeForce, StopModeGracefulThenForce))
+		return status, err
+	}
+
+	LogWarning("Instance %s did not stop gracefully within %s (status: %s), forcing stop", instanceID, policy.GracefulTimeout, status)
+	diags.AddWarning("Instance Force Stopped", fmt.Sprintf("Instance '%s' did not stop gracefully within %s and was force stopped.", instanceID, policy.GracefulTimeout))
+
+	if _, err := client.StopInstance(zoneID, instanceID, true); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to force stop instance, got error: %s", err))
+		return status, err
+	}
+
+	status, err = WaitForInstanceStatus(client, zoneID, instanceID, stoppedStatuses(), MaxInstanceStatusRetries, DefaultPollInterval)
+	if err != nil {
+		diags.AddError("Instance Not Stopped", fmt.Sprintf("Instance '%s' did not reach stopped state after a forced stop. Current status: %s. Error: %s", instanceID, status, err))
+		return status, err
+	}
+
+	return status, nil
+}
+
+func stoppedStatuses() []string {
+	return []string{InstanceStatusStopped, InstanceStatusSTOPPED, InstanceStatusDown}
+}
+
 type LifecycleResult struct {
 	Status types.String
 	Diags  diag.Diagnostics
 }
 
-func HandleInstanceLifecycle(client *http.Client, zoneID, instanceID, desiredState, currentStatus string) LifecycleResult {
+func HandleInstanceLifecycle(client *http.Client, zoneID, instanceID, desiredState, currentStatus string, policy StopPolicy) LifecycleResult {
 	result := LifecycleResult{}
 
-	if desiredState == "reboot" {
-		if IsInstanceRunning(currentStatus) {
-			_, err := client.RebootInstance(zoneID, instanceID)
-			if err != nil {
-				result.Diags.AddError("Client Error", fmt.Sprintf("Unable to reboot instance, got error: %s", err))
-				return result
-			}
-
-			status, err := WaitForInstanceStatus(client, zoneID, instanceID, []string{InstanceStatusRunning, InstanceStatusUP}, MaxInstanceStatusRetries, DefaultPollInterval)
-			if err != nil {
-				result.Diags.AddError("Client Error", fmt.Sprintf("Unable to read instance status after reboot, got error: %s", err))
-				return result
-			}
-			result.Status = types.StringValue(status)
-		} else {
-			result.Diags.AddWarning("Reboot Skipped", fmt.Sprintf("Instance is not running (status: %s), cannot reboot", currentStatus))
-			result.Status = types.StringValue(currentStatus)
-		}
-	} else if desiredState == "running" {
+	if desiredState == "running" {
 		if !IsInstanceRunning(currentStatus) {
 			_, err := client.StartInstance(zoneID, instanceID)
 			if err != nil {
@@ -108,14 +171,15 @@ func HandleInstanceLifecycle(client *http.Client, zoneID, instanceID, desiredSta
 		}
 	} else if desiredState == "stopped" {
 		if IsInstanceRunning(currentStatus) {
-			_, err := client.StopInstance(zoneID, instanceID, false)
+			status, err := StopInstanceWithPolicy(client, zoneID, instanceID, policy, &result.Diags)
 			if err != nil {
-				result.Diags.AddError("Client Error", fmt.Sprintf("Unable to stop instance, got error: %s", err))
 				return result
 			}
+			result.Status = types.StringValue(status)
+			return result
 		}
 
-		status, err := WaitForInstanceStatus(client, zoneID, instanceID, []string{InstanceStatusStopped, InstanceStatusSTOPPED, InstanceStatusDown}, MaxInstanceStatusRetries, DefaultPollInterval)
+		status, err := WaitForInstanceStatus(client, zoneID, instanceID, stoppedStatuses(), MaxInstanceStatusRetries, DefaultPollInterval)
 		if err != nil {
 			result.Diags.AddError("Client Error", fmt.Sprintf("Unable to read instance status after stop, got error: %s", err))
 			return result
@@ -128,6 +192,32 @@ func HandleInstanceLifecycle(client *http.Client, zoneID, instanceID, desiredSta
 	return result
 }
 
+// HandleInstanceReboot reboots the instance when it is running and warns
+// without failing when it is not.
+func HandleInstanceReboot(client *http.Client, zoneID, instanceID, currentStatus string) LifecycleResult {
+	result := LifecycleResult{}
+
+	if IsInstanceRunning(currentStatus) {
+		_, err := client.RebootInstance(zoneID, instanceID)
+		if err != nil {
+			result.Diags.AddError("Client Error", fmt.Sprintf("Unable to reboot instance, got error: %s", err))
+			return result
+		}
+
+		status, err := WaitForInstanceStatus(client, zoneID, instanceID, []string{InstanceStatusRunning, InstanceStatusUP}, MaxInstanceStatusRetries, DefaultPollInterval)
+		if err != nil {
+			result.Diags.AddError("Client Error", fmt.Sprintf("Unable to read instance status after reboot, got error: %s", err))
+			return result
+		}
+		result.Status = types.StringValue(status)
+	} else {
+		result.Diags.AddWarning("Reboot Skipped", fmt.Sprintf("Instance is not running (status: %s), cannot reboot", currentStatus))
+		result.Status = types.StringValue(currentStatus)
+	}
+
+	return result
+}
+
 func IsInstanceRunning(status string) bool {
 	return status == InstanceStatusRunning || status == InstanceStatusUP
 }
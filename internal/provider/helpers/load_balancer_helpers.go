@@ -0,0 +1,121 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
+)
+
+// LoadBalancerLiveStatus is the HAProxy runtime view of a load balancer rule.
+type LoadBalancerLiveStatus struct {
+	Status    string
+	UpdatedAt int64
+}
+
+// GetLoadBalancerLiveStatus returns the HAProxy runtime status of a rule, or
+// nil if HAProxy does not report the rule yet. The API only reports status per
+// rule, not per backend.
+func GetLoadBalancerLiveStatus(client *http.Client, zoneID, networkID, ruleID string) (*LoadBalancerLiveStatus, error) {
+	liveResp, err := client.GetHaproxyLive(zoneID, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read HAProxy live status: %w", err)
+	}
+	for _, rule := range liveResp.Data.Rules {
+		if rule.ID == ruleID {
+			return &LoadBalancerLiveStatus{Status: rule.Status, UpdatedAt: liveResp.Data.UpdatedAt}, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateLoadBalancerRule creates a rule and returns it once it appears in the
+// network's rule list. The new rule is identified by comparing the list
+// before and after the request.
+func CreateLoadBalancerRule(client *http.Client, zoneID, networkID, publicIPID, name, algorithm string, publicPort, privatePort int) (responses.LoadBalancerRule, error) {
+	before, err := client.ListLoadBalancerRules(zoneID, networkID)
+	if err != nil {
+		return responses.LoadBalancerRule{}, fmt.Errorf("unable to list load balancer rules before creation: %w", err)
+	}
+	existing := make(map[string]struct{}, len(before.Data))
+	for _, rule := range before.Data {
+		existing[rule.ID] = struct{}{}
+	}
+
+	createResp, err := client.CreateLoadBalancerRule(zoneID, networkID, publicIPID, name, algorithm, publicPort, privatePort)
+	if err != nil {
+		return responses.LoadBalancerRule{}, fmt.Errorf("unable to create load balancer rule: %w", err)
+	}
+	if !createResp.Data.Success {
+		return responses.LoadBalancerRule{}, fmt.Errorf("API returned failure for load balancer creation")
+	}
+
+	var found responses.LoadBalancerRule
+	checkFunc := func() (bool, error) {
+		after, err := client.ListLoadBalancerRules(zoneID, networkID)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range after.Data {
+			if _, ok := existing[rule.ID]; !ok {
+				found = rule
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	err = PollUntilCondition(checkFunc, MaxNetworkConnectionRetries, DefaultNetworkPollInterval, fmt.Sprintf("load balancer rule %q did not appear within timeout", name))
+	return found, err
+}
+
+// FindLoadBalancerRule returns the rule with the given ID on a network, or
+// nil if the network has no such rule.
+func FindLoadBalancerRule(client *http.Client, zoneID, networkID, ruleID string) (*responses.LoadBalancerRule, error) {
+	listResp, err := client.ListLoadBalancerRules(zoneID, networkID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range listResp.Data {
+		if rule.ID == ruleID {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteLoadBalancerRule deletes a rule.
+func DeleteLoadBalancerRule(client *http.Client, zoneID, networkID, ruleID string) error {
+	deleteResp, err := client.DeleteLoadBalancerRule(zoneID, networkID, ruleID)
+	if err != nil {
+		return fmt.Errorf("unable to delete load balancer rule %s: %w", ruleID, err)
+	}
+	if !deleteResp.Data.Success {
+		return fmt.Errorf("API returned failure for load balancer deletion")
+	}
+	return nil
+}
+
+// IsPortConflictError reports whether a rule creation failed because another
+// rule already uses the public port. The client does not expose status codes,
+// so the error text is matched: a 409 only appears as "status 409" when the
+// body is not JSON, and JSON error bodies are matched by their message.
+func IsPortConflictError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "status 409") {
+		return true
+	}
+	if !strings.Contains(msg, "port") {
+		return false
+	}
+	for _, hint := range []string{"in use", "already", "exist", "conflict", "duplicate", "taken"} {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+	return false
+}
@@ -121,6 +121,66 @@ func GetNetworkInstances(client *http.Client, zoneID, networkID string) ([]respo
 	return filtered, nil
 }
 
+// ListZoneL3NetworkIDs returns the IDs of the L3 (Isolated) networks in a
+// zone. Public IPs, load balancers and port forwarding only exist on L3
+// networks, and the API rejects those endpoints for L2 networks.
+func ListZoneL3NetworkIDs(client *http.Client, zoneID string) ([]string, error) {
+	networksResp, err := client.ListNetworks(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(networksResp.Data))
+	for _, network := range networksResp.Data {
+		if network.NetworkOffering.Type == "Isolated" {
+			ids = append(ids, network.ID)
+		}
+	}
+	return ids, nil
+}
+
+// NetworkNotFound reports whether a network is confirmed to no longer exist.
+// Any other lookup failure is returned so callers can keep their state.
+func NetworkNotFound(client *http.Client, zoneID, networkID string) (bool, error) {
+	_, err := client.ShowNetwork(zoneID, networkID)
+	if err == nil {
+		return false, nil
+	}
+	if IsNotFoundError(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// FindInstanceAttachment returns an instance's attachment (NIC) on a network,
+// or nil if the instance is not attached to it.
+func FindInstanceAttachment(client *http.Client, zoneID, networkID, instanceID string) (*responses.InstanceNetwork, error) {
+	listResp, err := client.ListNetworkInstances(zoneID, networkID, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list instances of network %s: %w", networkID, err)
+	}
+	for _, ni := range listResp.Data {
+		if ni.InstanceID == instanceID && ni.Network.ID == networkID {
+			return &ni, nil
+		}
+	}
+	return nil, nil
+}
+
+// DescribeNetworkAttachments renders instance attachments for diagnostics,
+// one "name (id) ip" entry per attachment.
+func DescribeNetworkAttachments(attachments []responses.InstanceNetwork) []string {
+	descriptions := make([]string, 0, len(attachments))
+	for _, ni := range attachments {
+		desc := fmt.Sprintf("%s (%s) %s", ni.InstanceName, ni.InstanceID, ni.IPAddress)
+		if ni.IsDefault {
+			desc += " [default NIC]"
+		}
+		descriptions = append(descriptions, desc)
+	}
+	sort.Strings(descriptions)
+	return descriptions
+}
+
 func ConnectNetworks(client *http.Client, zoneID, instanceID string, networkIDs []string, diags *diag.Diagnostics) ([]responses.InstanceNetwork, string) {
 	connectedNetworks := make(map[string]bool)
 	var instanceIP string
@@ -0,0 +1,204 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
+)
+
+const (
+	PortForwardProtocolTCP  = "TCP"
+	PortForwardProtocolUDP  = "UDP"
+	PortForwardProtocolBoth = "both"
+
+	MinPort = 1
+	MaxPort = 65535
+
+	// MaxPortForwardRangeSize caps the ports in one forward. The API takes
+	// one rule per port and protocol, so a range costs one call per port.
+	MaxPortForwardRangeSize = 256
+)
+
+// PortForwardSpec is a single public-to-private port mapping as accepted by
+// the API. Ranges and the "both" protocol are expanded into one spec per
+// protocol and port.
+type PortForwardSpec struct {
+	Protocol    string
+	PublicPort  int
+	PrivatePort int
+}
+
+// PortForwardProtocols returns the API protocols a configured protocol maps to.
+func PortForwardProtocols(protocol string) []string {
+	if protocol == PortForwardProtocolBoth {
+		return []string{PortForwardProtocolTCP, PortForwardProtocolUDP}
+	}
+	return []string{protocol}
+}
+
+// ExpandPortForwards maps publicStart..publicEnd onto privateStart.. port by
+// port for every protocol in protocol.
+func ExpandPortForwards(protocol string, publicStart, publicEnd, privateStart int) []PortForwardSpec {
+	var specs []PortForwardSpec
+	for _, proto := range PortForwardProtocols(protocol) {
+		for offset := 0; publicStart+offset <= publicEnd; offset++ {
+			specs = append(specs, PortForwardSpec{
+				Protocol:    proto,
+				PublicPort:  publicStart + offset,
+				PrivatePort: privateStart + offset,
+			})
+		}
+	}
+	return specs
+}
+
+// FindPortForwardCollisions returns the existing rules that forward a public
+// port in publicStart..publicEnd for one of the given protocols. Rules whose
+// IDs are in exclude are ignored. The API does not report which public IP a
+// forward belongs to, so every forward on the network is considered.
+func FindPortForwardCollisions(rules []responses.PortForwardRule, protocols []string, publicStart, publicEnd int, exclude map[string]struct{}) []responses.PortForwardRule {
+	var collisions []responses.PortForwardRule
+	for _, rule := range rules {
+		if _, ok := exclude[rule.ID]; ok {
+			continue
+		}
+		if rule.PublicPort < publicStart || rule.PublicPort > publicEnd {
+			continue
+		}
+		for _, proto := range protocols {
+			if rule.Protocol == proto {
+				collisions = append(collisions, rule)
+				break
+			}
+		}
+	}
+	SortPortForwardRules(collisions)
+	return collisions
+}
+
+// SortPortForwardRules orders rules by protocol, then public port, matching
+// the order ExpandPortForwards produces.
+func SortPortForwardRules(rules []responses.PortForwardRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Protocol != rules[j].Protocol {
+			return rules[i].Protocol < rules[j].Protocol
+		}
+		return rules[i].PublicPort < rules[j].PublicPort
+	})
+}
+
+// ResolveInstancePrivateIP returns the IP address of an instance on a network.
+func ResolveInstancePrivateIP(client *http.Client, zoneID, networkID, instanceID string) (string, error) {
+	attachment, err := FindInstanceAttachment(client, zoneID, networkID, instanceID)
+	if err != nil {
+		return "", err
+	}
+	if attachment == nil || attachment.IPAddress == "" {
+		return "", fmt.Errorf("instance %s is not attached to network %s", instanceID, networkID)
+	}
+	return attachment.IPAddress, nil
+}
+
+// CreatePortForwards creates one forward per spec and returns the new rules
+// once all of them appear in the network's forward list. New rules are
+// identified by comparing the list before and after the requests. If any
+// request fails, the rules created so far are deleted again.
+func CreatePortForwards(client *http.Client, zoneID, networkID, privateIP string, specs []PortForwardSpec) ([]responses.PortForwardRule, error) {
+	before, err := client.ListPortForwards(zoneID, networkID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list port forwards before creation: %w", err)
+	}
+	existing := make(map[string]struct{}, len(before.Data))
+	for _, rule := range before.Data {
+		existing[rule.ID] = struct{}{}
+	}
+
+	newRules := func() ([]responses.PortForwardRule, error) {
+		after, err := client.ListPortForwards(zoneID, networkID)
+		if err != nil {
+			return nil, err
+		}
+		var created []responses.PortForwardRule
+		for _, rule := range after.Data {
+			if _, ok := existing[rule.ID]; !ok {
+				created = append(created, rule)
+			}
+		}
+		return created, nil
+	}
+
+	for _, spec := range specs {
+		body := map[string]interface{}{
+			"network_id":   networkID,
+			"protocol":     spec.Protocol,
+			"public_port":  spec.PublicPort,
+			"private_port": spec.PrivatePort,
+			"private_ip":   privateIP,
+		}
+		if _, err := client.CreatePortForward(zoneID, body); err != nil {
+			createErr := fmt.Errorf("unable to create %s forward %d -> %s:%d: %w", spec.Protocol, spec.PublicPort, privateIP, spec.PrivatePort, err)
+			if created, listErr := newRules(); listErr == nil && len(created) > 0 {
+				if cleanupErr := DeletePortForwards(client, zoneID, networkID, PortForwardRuleIDs(created)); cleanupErr != nil {
+					return nil, fmt.Errorf("%w (cleanup of partially created forwards also failed: %v)", createErr, cleanupErr)
+				}
+			}
+			return nil, createErr
+		}
+	}
+
+	var created []responses.PortForwardRule
+	checkFunc := func() (bool, error) {
+		rules, err := newRules()
+		if err != nil {
+			return false, err
+		}
+		created = rules
+		return len(created) >= len(specs), nil
+	}
+
+	err = PollUntilCondition(checkFunc, MaxInstanceStatusRetries, DefaultPollInterval, fmt.Sprintf("not all %d port forwards appeared on network %s within timeout", len(specs), networkID))
+	SortPortForwardRules(created)
+	return created, err
+}
+
+// DeletePortForwards deletes the given forwards and waits until none of them
+// is listed on the network any more.
+func DeletePortForwards(client *http.Client, zoneID, networkID string, ruleIDs []string) error {
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+
+	pending := make(map[string]struct{}, len(ruleIDs))
+	for _, id := range ruleIDs {
+		if _, err := client.DeletePortForward(zoneID, id); err != nil {
+			return fmt.Errorf("unable to delete port forward %s: %w", id, err)
+		}
+		pending[id] = struct{}{}
+	}
+
+	checkFunc := func() (bool, error) {
+		listResp, err := client.ListPortForwards(zoneID, networkID)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range listResp.Data {
+			if _, ok := pending[rule.ID]; ok {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	return PollUntilCondition(checkFunc, MaxInstanceStatusRetries, DefaultPollInterval, fmt.Sprintf("port forwards on network %s were still listed after deletion", networkID))
+}
+
+// PortForwardRuleIDs returns the IDs of rules in order.
+func PortForwardRuleIDs(rules []responses.PortForwardRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
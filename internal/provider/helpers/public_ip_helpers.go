@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
+)
+
+// AssociatePublicIP allocates a new public IP on a network and returns it once
+// it appears in the network's public IP list. The new IP is identified by
+// comparing the list before and after the request.
+func AssociatePublicIP(client *http.Client, zoneID, networkID string) (responses.NetworkPublicIp, error) {
+	before, err := client.ListNetworkPublicIps(zoneID, networkID)
+	if err != nil {
+		return responses.NetworkPublicIp{}, fmt.Errorf("unable to list public IPs before association: %w", err)
+	}
+	existing := make(map[string]struct{}, len(before.Data))
+	for _, ip := range before.Data {
+		existing[ip.ID] = struct{}{}
+	}
+
+	associateResp, err := client.AssociateNetworkPublicIp(zoneID, networkID)
+	if err != nil {
+		return responses.NetworkPublicIp{}, fmt.Errorf("unable to associate public IP: %w", err)
+	}
+	if !associateResp.Data.Success {
+		return responses.NetworkPublicIp{}, fmt.Errorf("API returned failure for public IP association")
+	}
+
+	var found responses.NetworkPublicIp
+	checkFunc := func() (bool, error) {
+		after, err := client.ListNetworkPublicIps(zoneID, networkID)
+		if err != nil {
+			return false, err
+		}
+		for _, ip := range after.Data {
+			if _, ok := existing[ip.ID]; !ok {
+				found = ip
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	err = PollUntilCondition(checkFunc, MaxNetworkConnectionRetries*2, DefaultNetworkPollInterval, fmt.Sprintf("public IP associated with network %s did not appear within timeout", networkID))
+	return found, err
+}
+
+// FindPublicIP returns the public IP with the given ID on a network, or nil if
+// the network has no such IP.
+func FindPublicIP(client *http.Client, zoneID, networkID, publicIPID string) (*responses.NetworkPublicIp, error) {
+	listResp, err := client.ListNetworkPublicIps(zoneID, networkID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range listResp.Data {
+		if ip.ID == publicIPID {
+			return &ip, nil
+		}
+	}
+	return nil, nil
+}
+
+// EnableStaticNat maps a public IP one-to-one to an instance.
+func EnableStaticNat(client *http.Client, zoneID, networkID, publicIPID, instanceID string) error {
+	enableResp, err := client.EnableNetworkPublicIpStaticNat(zoneID, networkID, publicIPID, instanceID)
+	if err != nil {
+		return fmt.Errorf("unable to enable static NAT for instance %s: %w", instanceID, err)
+	}
+	if !enableResp.Data.Success {
+		return fmt.Errorf("API returned failure for static NAT enable")
+	}
+	return nil
+}
+
+// DisableStaticNat removes the static NAT mapping of a public IP.
+func DisableStaticNat(client *http.Client, zoneID, networkID, publicIPID string) error {
+	disableResp, err := client.DisableNetworkPublicIpStaticNat(zoneID, networkID, publicIPID)
+	if err != nil {
+		return fmt.Errorf("unable to disable static NAT: %w", err)
+	}
+	if !disableResp.Data.Success {
+		return fmt.Errorf("API returned failure for static NAT disable")
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
+)
+
+// SnapshotIntervals lists the schedule intervals supported by snapshot
+// policies, in ascending order, with the age at which a new snapshot is due.
+var SnapshotIntervals = []struct {
+	Name   string
+	Period time.Duration
+}{
+	{SnapshotIntervalHourly, time.Hour},
+	{SnapshotIntervalDaily, 24 * time.Hour},
+	{SnapshotIntervalWeekly, 7 * 24 * time.Hour},
+	{SnapshotIntervalMonthly, 30 * 24 * time.Hour},
+}
+
+// WaitForSnapshotReady polls the instance until a snapshot with the given name
+// exists and reports READY. The last seen snapshot is returned on timeout.
+func WaitForSnapshotReady(client *http.Client, zoneID, instanceID, name string, maxRetries int, interval time.Duration) (responses.InstanceSnapshot, error) {
+	var found responses.InstanceSnapshot
+	checkFunc := func() (bool, error) {
+		instanceResp, err := client.ShowInstance(zoneID, instanceID)
+		if err != nil {
+			return false, err
+		}
+		for _, snap := range instanceResp.Data.Snapshot {
+			if snap.Name == name {
+				found = snap
+				return snap.Status == SnapshotStatusReady, nil
+			}
+		}
+		return false, nil
+	}
+
+	err := PollUntilCondition(checkFunc, maxRetries, interval, fmt.Sprintf("Snapshot '%s' did not reach status '%s' within timeout", name, SnapshotStatusReady))
+	return found, err
+}
+
+// PolicySnapshot is a snapshot created by a snapshot policy. The interval and
+// time it was taken are encoded in its name.
+type PolicySnapshot struct {
+	ID       string
+	Name     string
+	Interval string
+	Status   string
+	TakenAt  time.Time
+}
+
+// SnapshotPolicyName builds the name of a policy snapshot.
+func SnapshotPolicyName(prefix, interval string, takenAt time.Time) string {
+	return fmt.Sprintf("%s-%s-%d", prefix, interval, takenAt.Unix())
+}
+
+// ParsePolicySnapshots returns the snapshots that belong to the policy with the
+// given prefix, newest first. Snapshots with other names are ignored.
+func ParsePolicySnapshots(prefix string, snapshots []responses.InstanceSnapshot) []PolicySnapshot {
+	result := make([]PolicySnapshot, 0)
+	for _, snap := range snapshots {
+		rest, ok := strings.CutPrefix(snap.Name, prefix+"-")
+		if !ok {
+			continue
+		}
+		interval, stamp, ok := strings.Cut(rest, "-")
+		if !ok || !isSnapshotInterval(interval) {
+			continue
+		}
+		unix, err := strconv.ParseInt(stamp, 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, PolicySnapshot{
+			ID:       snap.ID,
+			Name:     snap.Name,
+			Interval: interval,
+			Status:   snap.Status,
+			TakenAt:  time.Unix(unix, 0).UTC(),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TakenAt.After(result[j].TakenAt)
+	})
+	return result
+}
+
+// SnapshotPolicyActions is the work needed to bring an instance in line with
+// its snapshot policy.
+type SnapshotPolicyActions struct {
+	Create []string
+	Delete []PolicySnapshot
+}
+
+func (a SnapshotPolicyActions) Empty() bool {
+	return len(a.Create) == 0 && len(a.Delete) == 0
+}
+
+// Describe renders the actions as human readable lines for diagnostics.
+func (a SnapshotPolicyActions) Describe() []string {
+	lines := make([]string, 0, len(a.Create)+len(a.Delete))
+	for _, interval := range a.Create {
+		lines = append(lines, fmt.Sprintf("create %s snapshot", interval))
+	}
+	for _, snap := range a.Delete {
+		lines = append(lines, fmt.Sprintf("delete expired %s snapshot '%s' (%s) taken %s", snap.Interval, snap.Name, snap.ID, snap.TakenAt.Format(time.RFC3339)))
+	}
+	return lines
+}
+
+// PlanSnapshotPolicy works out which intervals are overdue for a new snapshot
+// and which existing snapshots exceed their retention count. schedule holds
+// the intervals to snapshot, retention the number of snapshots to keep per
+// interval; intervals without a retention entry keep none. snapshots must be
+// sorted newest first.
+func PlanSnapshotPolicy(snapshots []PolicySnapshot, schedule []string, retention map[string]int64, now time.Time) SnapshotPolicyActions {
+	actions := SnapshotPolicyActions{}
+
+	byInterval := make(map[string][]PolicySnapshot)
+	for _, snap := range snapshots {
+		byInterval[snap.Interval] = append(byInterval[snap.Interval], snap)
+	}
+
+	scheduled := make(map[string]bool)
+	for _, interval := range schedule {
+		scheduled[interval] = true
+	}
+
+	for _, interval := range SnapshotIntervals {
+		existing := byInterval[interval.Name]
+		keep := retention[interval.Name]
+
+		if scheduled[interval.Name] && keep > 0 {
+			if len(existing) == 0 || !now.Before(existing[0].TakenAt.Add(interval.Period)) {
+				actions.Create = append(actions.Create, interval.Name)
+				// The new snapshot counts towards retention.
+				keep--
+			}
+		}
+
+		if int64(len(existing)) > keep {
+			actions.Delete = append(actions.Delete, existing[keep:]...)
+		}
+	}
+
+	return actions
+}
+
+func isSnapshotInterval(name string) bool {
+	for _, interval := range SnapshotIntervals {
+		if interval.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func GetPolicySnapshotObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":       types.StringType,
+			"name":     types.StringType,
+			"interval": types.StringType,
+			"status":   types.StringType,
+			"taken_at": types.StringType,
+		},
+	}
+}
+
+func CreatePolicySnapshotsList(snapshots []PolicySnapshot, diags *diag.Diagnostics) types.List {
+	objects := make([]attr.Value, 0, len(snapshots))
+	for _, snap := range snapshots {
+		obj, objDiags := types.ObjectValue(
+			GetPolicySnapshotObjectType().AttrTypes,
+			map[string]attr.Value{
+				"id":       types.StringValue(snap.ID),
+				"name":     types.StringValue(snap.Name),
+				"interval": types.StringValue(snap.Interval),
+				"status":   types.StringValue(snap.Status),
+				"taken_at": types.StringValue(snap.TakenAt.Format(time.RFC3339)),
+			},
+		)
+		diags.Append(objDiags...)
+		objects = append(objects, obj)
+	}
+
+	list, listDiags := types.ListValue(GetPolicySnapshotObjectType(), objects)
+	diags.Append(listDiags...)
+	return list
+}
+
+// RevertToSnapshot reverts an instance to a snapshot and waits for the
+// instance to come back up. A slow recovery is reported as a warning.
+func RevertToSnapshot(client *http.Client, zoneID, instanceID, snapshotID string, diags *diag.Diagnostics) error {
+	revertResp, err := client.RevertInstanceSnapshot(zoneID, instanceID, snapshotID)
+	if err != nil {
+		diags.AddError(
+			"Snapshot Revert Failed",
+			fmt.Sprintf("Failed to revert instance %s to snapshot %s in zone %s. Error: %s", instanceID, snapshotID, zoneID, err),
+		)
+		return err
+	}
+
+	if !revertResp.Data.Success {
+		diags.AddError(
+			"Snapshot Revert Failed",
+			fmt.Sprintf("API returned failure for snapshot revert '%s'", snapshotID),
+		)
+		return fmt.Errorf("snapshot revert '%s' was not successful", snapshotID)
+	}
+
+	status, err := WaitForInstanceStatus(client, zoneID, instanceID, []string{InstanceStatusUP, InstanceStatusRunning}, MaxInstanceStatusRetries, DefaultPollInterval)
+	if err != nil {
+		diags.AddWarning(
+			"Instance Not Running After Revert",
+			fmt.Sprintf("Instance %s was reverted to snapshot %s but did not report a running status within timeout. Current status: %s. Error: %s", instanceID, snapshotID, status, err),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// VPNCredentials holds what a client needs to connect to a network's L2TP/IPsec
+// remote access VPN.
+type VPNCredentials struct {
+	Name         string
+	Server       string
+	Username     string
+	Password     string
+	PresharedKey string
+	// Subnet is the network routed through the VPN, e.g. "10.0.1.0/24". It is
+	// empty when the network's subnet is unknown.
+	Subnet string
+}
+
+// VPNClientConfig is a set of ready-to-use client configuration files.
+type VPNClientConfig struct {
+	StrongSwanIPsecConf    string
+	StrongSwanIPsecSecrets string
+	XL2TPDConf             string
+	PPPOptions             string
+	NetworkManagerKeyfile  string
+}
+
+// RenderVPNClientConfig renders client configurations for an L2TP over IPsec
+// VPN using a pre-shared key. Secrets are quoted and escaped for each file
+// format; secrets containing control characters such as newlines cannot be
+// written safely to every format and are rejected.
+func RenderVPNClientConfig(creds VPNCredentials) (VPNClientConfig, error) {
+	for name, value := range map[string]string{
+		"username":       creds.Username,
+		"password":       creds.Password,
+		"pre-shared key": creds.PresharedKey,
+	} {
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return VPNClientConfig{}, fmt.Errorf("the VPN %s contains control characters and cannot be written to client configuration files", name)
+		}
+	}
+
+	var ipsecConf strings.Builder
+	fmt.Fprintf(&ipsecConf, "conn %s\n", creds.Name)
+	ipsecConf.WriteString("    keyexchange=ikev1\n")
+	ipsecConf.WriteString("    authby=secret\n")
+	ipsecConf.WriteString("    type=transport\n")
+	ipsecConf.WriteString("    left=%defaultroute\n")
+	ipsecConf.WriteString("    leftprotoport=17/1701\n")
+	fmt.Fprintf(&ipsecConf, "    right=%s\n", creds.Server)
+	ipsecConf.WriteString("    rightprotoport=17/1701\n")
+	ipsecConf.WriteString("    ike=aes256-sha1-modp1024,aes128-sha1-modp1024,3des-sha1-modp1024!\n")
+	ipsecConf.WriteString("    esp=aes256-sha1,aes128-sha1,3des-sha1!\n")
+	ipsecConf.WriteString("    auto=add\n")
+
+	var xl2tpd strings.Builder
+	fmt.Fprintf(&xl2tpd, "[lac %s]\n", creds.Name)
+	fmt.Fprintf(&xl2tpd, "lns = %s\n", creds.Server)
+	xl2tpd.WriteString("ppp debug = no\n")
+	fmt.Fprintf(&xl2tpd, "pppoptfile = /etc/ppp/options.l2tpd.%s\n", creds.Name)
+	xl2tpd.WriteString("length bit = yes\n")
+
+	var ppp strings.Builder
+	ppp.WriteString("ipcp-accept-local\n")
+	ppp.WriteString("ipcp-accept-remote\n")
+	ppp.WriteString("refuse-eap\n")
+	ppp.WriteString("require-mschap-v2\n")
+	ppp.WriteString("noccp\n")
+	ppp.WriteString("noauth\n")
+	ppp.WriteString("mtu 1280\n")
+	ppp.WriteString("mru 1280\n")
+	ppp.WriteString("noipdefault\n")
+	ppp.WriteString("connect-delay 5000\n")
+	fmt.Fprintf(&ppp, "name %s\n", quotePPPOption(creds.Username))
+	fmt.Fprintf(&ppp, "password %s\n", quotePPPOption(creds.Password))
+
+	var keyfile strings.Builder
+	keyfile.WriteString("[connection]\n")
+	fmt.Fprintf(&keyfile, "id=%s\n", creds.Name)
+	keyfile.WriteString("type=vpn\n")
+	keyfile.WriteString("autoconnect=false\n")
+	keyfile.WriteString("\n[vpn]\n")
+	keyfile.WriteString("service-type=org.freedesktop.NetworkManager.l2tp\n")
+	fmt.Fprintf(&keyfile, "gateway=%s\n", creds.Server)
+	fmt.Fprintf(&keyfile, "user=%s\n", escapeKeyfileValue(creds.Username))
+	keyfile.WriteString("password-flags=0\n")
+	keyfile.WriteString("ipsec-enabled=yes\n")
+	keyfile.WriteString("ipsec-psk-flags=0\n")
+	keyfile.WriteString("\n[vpn-secrets]\n")
+	fmt.Fprintf(&keyfile, "password=%s\n", escapeKeyfileValue(creds.Password))
+	fmt.Fprintf(&keyfile, "ipsec-psk=%s\n", escapeKeyfileValue(creds.PresharedKey))
+	keyfile.WriteString("\n[ipv4]\n")
+	keyfile.WriteString("method=auto\n")
+	if creds.Subnet != "" {
+		// Only route the network itself through the tunnel.
+		keyfile.WriteString("never-default=true\n")
+		fmt.Fprintf(&keyfile, "route1=%s\n", creds.Subnet)
+	}
+	keyfile.WriteString("\n[ipv6]\n")
+	keyfile.WriteString("method=ignore\n")
+
+	return VPNClientConfig{
+		StrongSwanIPsecConf:    ipsecConf.String(),
+		StrongSwanIPsecSecrets: fmt.Sprintf("%%any %s : PSK %s\n", creds.Server, ipsecSecret(creds.PresharedKey)),
+		XL2TPDConf:             xl2tpd.String(),
+		PPPOptions:             ppp.String(),
+		NetworkManagerKeyfile:  keyfile.String(),
+	}, nil
+}
+
+// ipsecSecret renders a secret for ipsec.secrets. Quoted strings there have no
+// escape sequences, so secrets containing a quote or backslash are written in
+// strongSwan's base64 ("0s") form instead.
+func ipsecSecret(value string) string {
+	if strings.ContainsAny(value, "\"\\") {
+		return "0s" + base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	return "\"" + value + "\""
+}
+
+// quotePPPOption quotes a pppd option argument so that whitespace and "#" are
+// kept, escaping backslashes and quotes.
+func quotePPPOption(value string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value) + "\""
+}
+
+// escapeKeyfileValue escapes a value for a GKeyFile-based NetworkManager
+// keyfile, where backslash starts an escape and leading spaces are dropped.
+func escapeKeyfileValue(value string) string {
+	escaped := strings.ReplaceAll(value, "\\", "\\\\")
+	if strings.HasPrefix(escaped, " ") {
+		escaped = "\\s" + escaped[1:]
+	}
+	return escaped
+}
+
+func GetVPNClientConfigObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"strongswan_ipsec_conf":    types.StringType,
+			"strongswan_ipsec_secrets": types.StringType,
+			"xl2tpd_conf":              types.StringType,
+			"ppp_options":              types.StringType,
+			"networkmanager_keyfile":   types.StringType,
+		},
+	}
+}
+
+func CreateVPNClientConfigObject(config VPNClientConfig, diags *diag.Diagnostics) types.Object {
+	obj, d := types.ObjectValue(GetVPNClientConfigObjectType().AttrTypes, map[string]attr.Value{
+		"strongswan_ipsec_conf":    types.StringValue(config.StrongSwanIPsecConf),
+		"strongswan_ipsec_secrets": types.StringValue(config.StrongSwanIPsecSecrets),
+		"xl2tpd_conf":              types.StringValue(config.XL2TPDConf),
+		"ppp_options":              types.StringValue(config.PPPOptions),
+		"networkmanager_keyfile":   types.StringValue(config.NetworkManagerKeyfile),
+	})
+	diags.Append(d...)
+	return obj
+}
+
+// ParseRotationPeriod parses a duration such as "720h" or "30d". Go duration
+// syntax is accepted, plus a whole number of days with a "d" suffix.
+func ParseRotationPeriod(value string) (time.Duration, error) {
+	var period time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid duration", value)
+		}
+		period = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid duration", value)
+		}
+		period = d
+	}
+	if period <= 0 {
+		return 0, fmt.Errorf("%q must be a positive duration", value)
+	}
+	return period, nil
+}
+
+// VPNRotationDue reports whether credentials rotated at rotatedAt (RFC 3339)
+// are older than rotateAfter at now. Missing or invalid values never make a
+// rotation due.
+func VPNRotationDue(rotatedAt, rotateAfter string, now time.Time) bool {
+	if rotatedAt == "" || rotateAfter == "" {
+		return false
+	}
+	period, err := ParseRotationPeriod(rotateAfter)
+	if err != nil {
+		return false
+	}
+	last, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	return !now.Before(last.Add(period))
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,6 +19,9 @@ import (
 
 // Ensure the implementation satisfies the provider.Provider interface.
 // var _ provider.Provider = &virakCloudProvider{}
+var _ provider.ProviderWithActions = &virakCloudProvider{}
+var _ provider.ProviderWithEphemeralResources = &virakCloudProvider{}
+var _ provider.ProviderWithFunctions = &virakCloudProvider{}
 
 type virakCloudProvider struct {
 	version     string
@@ -83,6 +89,8 @@ func (p *virakCloudProvider) Configure(ctx context.Context, req provider.Configu
 
 	resp.ResourceData = client
 	resp.DataSourceData = client
+	resp.ActionData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *virakCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -93,10 +101,17 @@ func (p *virakCloudProvider) Resources(ctx context.Context) []func() resource.Re
 		func() resource.Resource { return NewKubernetesClusterResource(&p.createMutex) },
 		func() resource.Resource { return NewDnsDomainResource(&p.createMutex) },
 		func() resource.Resource { return NewDnsRecordResource(&p.createMutex) },
+		func() resource.Resource { return NewDnsZoneRecordsResource(&p.createMutex) },
 		func() resource.Resource { return NewVolumeResource(&p.createMutex) },
 		func() resource.Resource { return NewSnapshotResource(&p.createMutex) },
+		func() resource.Resource { return NewSnapshotPolicyResource(&p.createMutex) },
 		func() resource.Resource { return NewFirewallRuleResource(&p.createMutex) },
+		func() resource.Resource { return NewFirewallRulesetResource(&p.createMutex) },
+		func() resource.Resource { return NewSecurityGroupResource() },
+		func() resource.Resource { return NewSecurityGroupBindingResource(&p.createMutex) },
 		func() resource.Resource { return NewPublicIPResource(&p.createMutex) },
+		func() resource.Resource { return NewPublicIPAssociationResource(&p.createMutex) },
+		func() resource.Resource { return NewPublicIPStaticNatResource(&p.createMutex) },
 		func() resource.Resource { return NewLoadBalancerResource(&p.createMutex) },
 		func() resource.Resource { return NewLoadBalancerBackendResource(&p.createMutex) },
 		func() resource.Resource { return NewNetworkVPNResource(&p.createMutex) },
@@ -114,12 +129,39 @@ func (p *virakCloudProvider) DataSources(ctx context.Context) []func() datasourc
 		NewKubernetesVersionsDataSource,
 		NewNetworkOfferingsDataSource,
 		NewNetworksDataSource,
+		NewNetworkCIDRAllocatorDataSource,
+		NewPublicIPsDataSource,
+		NewLoadBalancersDataSource,
+		NewPortForwardingRulesDataSource,
+		NewDnsZoneFileDataSource,
 		NewVolumeOfferingsDataSource,
 		NewZoneServicesDataSource,
 		// ... other data sources
 	}
 }
 
+func (p *virakCloudProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewNetworkVPNCredentialsEphemeralResource,
+	}
+}
+
+func (p *virakCloudProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		func() action.Action { return NewSnapshotRevertAction(&p.createMutex) },
+		func() action.Action { return NewInstanceStartAction(&p.createMutex) },
+		func() action.Action { return NewInstanceStopAction(&p.createMutex) },
+		func() action.Action { return NewInstanceRebootAction(&p.createMutex) },
+	}
+}
+
+func (p *virakCloudProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseZoneFileFunction,
+		NewInspectCertificateFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &virakCloudProvider{
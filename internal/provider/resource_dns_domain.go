@@ -2,28 +2,45 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"net"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 var _ resource.Resource = &dnsDomainResource{}
 
+// delegationLookupTimeout bounds the public NS lookup made on every refresh.
+const delegationLookupTimeout = 10 * time.Second
+
 func NewDnsDomainResource(mutex *sync.Mutex) resource.Resource {
-	return &dnsDomainResource{mutex: mutex}
+	return &dnsDomainResource{mutex: mutex, resolver: net.DefaultResolver, pollInterval: helpers.DefaultDelegationPollInterval}
 }
 
 type dnsDomainResource struct {
-	client *http.Client
-	mutex  *sync.Mutex
+	client       *http.Client
+	mutex        *sync.Mutex
+	resolver     helpers.NSResolver
+	pollInterval time.Duration
 }
 
 func (r *dnsDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -32,7 +49,7 @@ func (r *dnsDomainResource) Metadata(ctx context.Context, req resource.MetadataR
 
 func (r *dnsDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Virak Cloud DNS domain.",
+		MarkdownDescription: "Manages a Virak Cloud DNS domain and exposes the nameservers to configure at the registrar.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -44,14 +61,96 @@ func (r *dnsDomainResource) Schema(ctx context.Context, req resource.SchemaReque
 			"domain": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The domain name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The status of the DNS domain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"dns_info": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "DNS information for the domain.",
+				MarkdownDescription: "DNS information for the domain, as JSON.",
+				DeprecationMessage:  "Use nameservers, delegated_nameservers and delegation_status instead.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The Virak DNS nameservers assigned to the domain, to be configured at the registrar.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"delegated_nameservers": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The nameservers the domain is publicly delegated to. Looked up through the system resolver, falling back to what Virak DNS reports when the lookup fails. " +
+					"The resolver is a caching recursive resolver, so after a registrar change this can lag behind for up to the TTL of the previous NS records (often 1-2 days).",
+			},
+			"delegation_status": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Whether `delegated_nameservers` match `nameservers`: " +
+					"'delegated', 'partial' (some match), 'not_delegated' or 'unknown' (no nameservers assigned).",
+			},
+			"soa": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The domain's SOA record. Null until Virak DNS has created it.",
+				Attributes: map[string]schema.Attribute{
+					"primary_ns": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The primary nameserver (MNAME).",
+					},
+					"hostmaster": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The responsible mailbox (RNAME), in DNS notation.",
+					},
+					"serial": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "The zone serial number.",
+					},
+					"refresh": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Secondary refresh interval in seconds.",
+					},
+					"retry": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Secondary retry interval in seconds.",
+					},
+					"expire": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Seconds after which secondaries stop answering without a refresh.",
+					},
+					"minimum_ttl": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Negative caching TTL in seconds.",
+					},
+				},
+			},
+			"wait_for_delegation": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Poll public DNS until the domain is delegated to `nameservers`, so dependent resources only run once the zone is live. " +
+					"The wait runs on creation and on updates, such as turning this on or changing `delegation_timeout`; refreshes only report `delegation_status` and do not plan changes. " +
+					"Answers come from the system's caching resolver, so a wait can outlast the registrar change by the TTL of the previous NS records. " +
+					"On creation a timeout is only a warning, so the domain is not replaced; " +
+					"do not enable it in the same apply as a registrar resource that reads `nameservers`, since that resource cannot run until the wait ends.",
+			},
+			"delegation_timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(int64(helpers.DefaultDelegationTimeout.Seconds())),
+				MarkdownDescription: "Seconds to wait for delegation when `wait_for_delegation` is set. Defaults to 1800.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 		},
 	}
@@ -70,46 +169,73 @@ func (r *dnsDomainResource) Configure(ctx context.Context, req resource.Configur
 }
 
 func (r *dnsDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
 	var data models.DomainResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	r.createDomain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The delegation wait runs without the provider mutex so that it does not
+	// block every other resource for up to delegation_timeout.
+	if data.WaitForDelegation.ValueBool() {
+		// Failing here would taint the domain and replace it with all its
+		// records, so a timeout is reported as a warning and retried on the
+		// next apply.
+		var waitDiags diag.Diagnostics
+		r.waitForDelegation(ctx, &data, &waitDiags)
+		for _, d := range waitDiags {
+			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// createDomain creates the domain unless it already exists and refreshes data
+// from it, holding the provider mutex.
+func (r *dnsDomainResource) createDomain(ctx context.Context, data *models.DomainResourceModel, diags *diag.Diagnostics) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	// First, get the list of domains to check if it already exists
 	domainsResp, err := r.client.GetDomains()
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
 		return
 	}
 
 	// Check if the domain already exists
-	for _, domain := range domainsResp.Data {
+	var existing *responses.Domain
+	for i, domain := range domainsResp.Data {
 		if domain.Domain == data.Domain.ValueString() {
-			// Domain exists, use the existing one
-			data.ID = types.StringValue(domain.Domain)
-			data.Status = types.StringValue(domain.Status)
-			data.DNSInfo = types.StringValue("{}") // Placeholder for DNS info
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			return
+			existing = &domainsResp.Data[i]
+			break
 		}
 	}
 
-	// Domain does not exist, create it
-	_, err = r.client.CreateDomain(data.Domain.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
-		return
+	if existing == nil {
+		// Domain does not exist, create it
+		_, err = r.client.CreateDomain(data.Domain.ValueString())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
+			return
+		}
+
+		domainResp, err := r.client.GetDomain(data.Domain.ValueString())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read domain '%s' after create, got error: %s", data.Domain.ValueString(), err))
+			return
+		}
+		existing = &domainResp.Data
 	}
 
 	data.ID = types.StringValue(data.Domain.ValueString())
-	data.Status = types.StringValue("active") // Assume active after create
-	data.DNSInfo = types.StringValue("{}")    // Placeholder for DNS info
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.refresh(ctx, data, existing, diags)
 }
 
 func (r *dnsDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -138,16 +264,54 @@ func (r *dnsDomainResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	data.Status = types.StringValue(domainResp.Data.Status)
-	data.DNSInfo = types.StringValue("{}") // Placeholder for DNS info
+	r.refresh(ctx, &data, &domainResp.Data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.WaitForDelegation.IsNull() {
+		data.WaitForDelegation = types.BoolValue(false)
+	}
+	if data.DelegationTimeout.IsNull() {
+		data.DelegationTimeout = types.Int64Value(int64(helpers.DefaultDelegationTimeout.Seconds()))
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *dnsDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only wait_for_delegation and delegation_timeout can change in place.
+	var data models.DomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.readDomain(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// As in Create, the delegation wait runs without the provider mutex.
+	if data.WaitForDelegation.ValueBool() {
+		r.waitForDelegation(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readDomain refreshes data from the API, holding the provider mutex.
+func (r *dnsDomainResource) readDomain(ctx context.Context, data *models.DomainResourceModel, diags *diag.Diagnostics) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	// Domains may not support updates
+	domainResp, err := r.client.GetDomain(data.Domain.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read domain '%s', got error: %s", data.Domain.ValueString(), err))
+		return
+	}
+	r.refresh(ctx, data, &domainResp.Data, diags)
 }
 
 func (r *dnsDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -166,3 +330,86 @@ func (r *dnsDomainResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 }
+
+// refresh sets the computed attributes from the domain details, the SOA
+// record and the public delegation of the domain.
+func (r *dnsDomainResource) refresh(ctx context.Context, data *models.DomainResourceModel, domain *responses.Domain, diags *diag.Diagnostics) {
+	name := data.Domain.ValueString()
+	data.Status = types.StringValue(domain.Status)
+
+	dnsInfo, err := json.Marshal(domain.DNSInfo)
+	if err != nil {
+		dnsInfo = []byte("{}")
+	}
+	data.DNSInfo = types.StringValue(string(dnsInfo))
+
+	assigned := make([]string, 0, len(domain.DNSInfo.VirakDNS))
+	for _, ns := range domain.DNSInfo.VirakDNS {
+		if ns != nil {
+			assigned = append(assigned, *ns)
+		}
+	}
+	assigned = helpers.NormalizeNameservers(assigned)
+	nameservers, d := types.ListValueFrom(ctx, types.StringType, assigned)
+	diags.Append(d...)
+	data.Nameservers = nameservers
+
+	lookupCtx, cancel := context.WithTimeout(ctx, delegationLookupTimeout)
+	defer cancel()
+	delegated, err := helpers.LookupDelegation(lookupCtx, r.resolver, name)
+	if err != nil {
+		log.Printf("Public NS lookup for %s failed, using the nameservers reported by Virak DNS: %s", name, err)
+		delegated = helpers.NormalizeNameservers(domain.DNSInfo.DomainDNS)
+	}
+	r.setDelegation(ctx, data, delegated, diags)
+
+	data.SOA = helpers.DNSSOAObjectValue(nil)
+	recordsResp, err := r.client.GetRecords(name)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s, got error: %s", name, err))
+		return
+	}
+	for _, rec := range recordsResp.Data {
+		if !strings.EqualFold(rec.Type, "SOA") || len(rec.Content) == 0 {
+			continue
+		}
+		soa, err := helpers.ParseSOAContent(rec.Content[0].ContentRaw)
+		if err != nil {
+			log.Printf("Unable to parse SOA record of %s: %s", name, err)
+			break
+		}
+		data.SOA = helpers.DNSSOAObjectValue(soa)
+		break
+	}
+}
+
+func (r *dnsDomainResource) setDelegation(ctx context.Context, data *models.DomainResourceModel, delegated []string, diags *diag.Diagnostics) {
+	var assigned []string
+	diags.Append(data.Nameservers.ElementsAs(ctx, &assigned, false)...)
+
+	delegatedValue, d := types.ListValueFrom(ctx, types.StringType, delegated)
+	diags.Append(d...)
+	data.DelegatedNameservers = delegatedValue
+	data.DelegationStatus = types.StringValue(helpers.CompareDelegation(assigned, delegated))
+}
+
+func (r *dnsDomainResource) waitForDelegation(ctx context.Context, data *models.DomainResourceModel, diags *diag.Diagnostics) {
+	if data.DelegationStatus.ValueString() == helpers.DelegationStatusDelegated {
+		return
+	}
+
+	var assigned []string
+	diags.Append(data.Nameservers.ElementsAs(ctx, &assigned, false)...)
+	timeout := time.Duration(data.DelegationTimeout.ValueInt64()) * time.Second
+
+	log.Printf("=== Waiting up to %s for %s to be delegated to %s ===", timeout, data.Domain.ValueString(), strings.Join(assigned, ", "))
+	delegated, err := helpers.WaitForDelegation(ctx, r.resolver, data.Domain.ValueString(), assigned, timeout, r.pollInterval)
+	if delegated != nil {
+		r.setDelegation(ctx, data, delegated, diags)
+	}
+	if err != nil {
+		diags.AddError("Delegation Timeout", fmt.Sprintf("%s. Point the domain's NS records at the registrar to: %s.", err, strings.Join(assigned, ", ")))
+		return
+	}
+	log.Printf("✓ %s is delegated to Virak DNS", data.Domain.ValueString())
+}
@@ -3,21 +3,26 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
-	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 var _ resource.Resource = &dnsRecordResource{}
+var _ resource.ResourceWithImportState = &dnsRecordResource{}
+var _ resource.ResourceWithValidateConfig = &dnsRecordResource{}
 
 func NewDnsRecordResource(mutex *sync.Mutex) resource.Resource {
 	return &dnsRecordResource{mutex: mutex}
@@ -46,19 +51,38 @@ func (r *dnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 			"domain": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The parent domain name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"record": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The record name (e.g., 'www').",
+				MarkdownDescription: "The record name (e.g., 'www'), relative to the domain, `@` for the apex, or fully qualified with a trailing dot.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(dnsRecordNameChanged,
+						"Changing the record name to a different name forces replacement.",
+						"Changing the record name to a different name forces replacement."),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The record type (A, MX, SRV, etc.).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !strings.EqualFold(req.StateValue.ValueString(), req.PlanValue.ValueString())
+					}, "Changing the record type forces replacement.", "Changing the record type forces replacement."),
+				},
 			},
 			"content": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The record content.",
 			},
+			"allow_overwrite": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Take over an existing value with the same record, type and content (or any value of a CNAME) instead of failing. Other values of the same record and type are always left alone.",
+			},
 			"ttl": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
@@ -99,7 +123,10 @@ func (r *dnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 			},
 			"content_id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "API-assigned content ID for the record.",
+				MarkdownDescription: "API-assigned content ID of this value. Each value of a multi-value record has its own.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -125,6 +152,29 @@ func (r *dnsRecordResource) Configure(ctx context.Context, req resource.Configur
 	r.client = client
 }
 
+func (r *dnsRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.DnsRecordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	helpers.ValidateDNSRecordConfig(models.DnsZoneRecordModel{
+		Record:   data.Record,
+		Type:     data.Type,
+		Content:  data.Content,
+		TTL:      data.TTL,
+		Priority: data.Priority,
+		Weight:   data.Weight,
+		Port:     data.Port,
+		Flags:    data.Flags,
+		Tag:      data.Tag,
+		License:  data.License,
+		Choicer:  data.Choicer,
+		Match:    data.Match,
+	}, data.Domain.ValueString(), path.Empty(), &resp.Diagnostics)
+}
+
 func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
@@ -137,9 +187,9 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Extract values
 	domain := data.Domain.ValueString()
-	record := data.Record.ValueString()
+	record := helpers.DNSAPIRecordName(data.Record.ValueString())
 	recordType := data.Type.ValueString()
-	content := data.Content.ValueString()
+	content := helpers.DNSAPIContent(recordType, data.Content.ValueString())
 	ttl := int(data.TTL.ValueInt64())
 	priority := int(data.Priority.ValueInt64())
 	weight := int(data.Weight.ValueInt64())
@@ -150,63 +200,70 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	choicer := int(data.Choicer.ValueInt64())
 	match := int(data.Match.ValueInt64())
 
-	// Check if record already exists
 	recordsResp, err := r.client.GetRecords(domain)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to fetch existing records, got error: %s", err))
 		return
 	}
 
-	// Look for existing record with same name and type
-	var existingContentID string
-	for _, rec := range recordsResp.Data {
-		recordFull := record
-		if !strings.Contains(record, domain) {
-			recordFull = record + "." + domain
-		}
-		if (rec.Name == record || rec.Name == recordFull) && rec.Type == recordType {
-			if len(rec.Content) > 0 {
-				existingContentID = rec.Content[0].ID
-			}
+	// Another value of the same name and type is fine (round-robin records),
+	// but the same value, or any value of a single-value type, collides.
+	existing := helpers.FindDNSRecordValues(recordsResp.Data, record, recordType, domain)
+	var collision *helpers.DNSRecordValue
+	for i := range existing {
+		if helpers.IsSingleValueDNSType(recordType) ||
+			helpers.NormalizeDNSContent(recordType, existing[i].Content) == helpers.NormalizeDNSContent(recordType, content) {
+			collision = &existing[i]
 			break
 		}
 	}
 
-	if existingContentID != "" {
-		// Record exists, update it instead
-		_, err := r.client.UpdateRecord(domain, record, recordType, existingContentID, content, ttl, priority, weight, port, flags, tag, license, choicer, match)
+	if collision != nil {
+		if !data.AllowOverwrite.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("record"),
+				"DNS Record Already Exists",
+				fmt.Sprintf("%s record %q in %s already has the value %q (content ID %s). "+
+					"Import it using the ID %s/%s/%s/%s, or set allow_overwrite = true to take it over.",
+					recordType, record, domain, collision.Content, collision.ContentID,
+					domain, record, recordType, collision.ContentID),
+			)
+			return
+		}
+
+		log.Printf("Taking over existing DNS record %s %s %q (content ID %s)", record, recordType, collision.Content, collision.ContentID)
+		_, err := r.client.UpdateRecord(domain, record, recordType, collision.ContentID, content, ttl, priority, weight, port, flags, tag, license, choicer, match)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update existing DNS record, got error: %s", err))
 			return
 		}
-		data.ContentID = types.StringValue(existingContentID)
+		data.ContentID = types.StringValue(collision.ContentID)
 	} else {
-		// Record doesn't exist, create it
+		known := map[string]bool{}
+		for _, value := range existing {
+			known[value.ContentID] = true
+		}
+
 		_, err := r.client.CreateRecord(domain, record, recordType, content, ttl, priority, weight, port, flags, tag, license, choicer, match)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create DNS record, got error: %s", err))
 			return
 		}
 
-		// Fetch records again to get content_id
+		// Fetch records again to find the content_id of the new value
 		recordsResp, err := r.client.GetRecords(domain)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to fetch records after create, got error: %s", err))
 			return
 		}
 
-		// Find the newly created record
 		var contentID string
-		for _, rec := range recordsResp.Data {
-			recordFull := record
-			if !strings.Contains(record, domain) {
-				recordFull = record + "." + domain
+		for _, value := range helpers.FindDNSRecordValues(recordsResp.Data, record, recordType, domain) {
+			if known[value.ContentID] {
+				continue
 			}
-			if (rec.Name == record || rec.Name == recordFull) && rec.Type == recordType {
-				if len(rec.Content) > 0 {
-					contentID = rec.Content[0].ID
-				}
-				break
+			if contentID == "" || helpers.NormalizeDNSContent(recordType, value.Content) == helpers.NormalizeDNSContent(recordType, content) {
+				contentID = value.ContentID
 			}
 		}
 		if contentID == "" {
@@ -220,24 +277,14 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	data.ID = types.StringValue(fmt.Sprintf("%s/%s/%s/%s", domain, record, recordType, data.ContentID.ValueString()))
 
 	// Fetch the record to get status and is_protected
-	latestRecordsResp, err := r.client.GetRecords(domain)
+	value, err := r.findValue(domain, record, recordType, data.ContentID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to fetch records after operation, got error: %s", err))
 		return
 	}
-
-	// Find the record
-	for _, rec := range latestRecordsResp.Data {
-		if rec.Name == record && rec.Type == recordType {
-			for _, c := range rec.Content {
-				if c.ID == data.ContentID.ValueString() {
-					data.Status = types.StringValue(rec.Status)
-					data.IsProtected = types.BoolValue(rec.IsProtected)
-					break
-				}
-			}
-			break
-		}
+	if value != nil {
+		data.Status = types.StringValue(value.Status)
+		data.IsProtected = types.BoolValue(value.Protected)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -261,49 +308,39 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	recordType := parts[2]
 	contentID := parts[3]
 
-	// Fetch records
-	recordsResp, err := r.client.GetRecords(domain)
+	// Find the value by content ID; other values of the same record and type
+	// belong to other resources.
+	value, err := r.findValue(domain, record, recordType, contentID)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records, got error: %s", err))
 		return
 	}
-
-	// Find the record
-	var foundRecord *responses.Record
-	var foundContent *responses.Content
-	for _, rec := range recordsResp.Data {
-		recordFull := record
-		if !strings.Contains(record, domain) {
-			recordFull = record + "." + domain
-		}
-		if (rec.Name == record || rec.Name == recordFull) && rec.Type == recordType {
-			for _, c := range rec.Content {
-				if c.ID == contentID {
-					foundRecord = &rec
-					foundContent = &c
-					break
-				}
-			}
-			if foundContent != nil {
-				break
-			}
-		}
-	}
-	if foundContent == nil {
+	if value == nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	// Populate data
 	data.Domain = types.StringValue(domain)
-	data.Record = types.StringValue(foundRecord.Name)
-	data.Type = types.StringValue(foundRecord.Type)
-	data.Content = types.StringValue(foundContent.ContentRaw)
-	data.TTL = types.Int64Value(int64(foundRecord.TTL))
+	// Keep the configured spelling when it is equivalent to what the API
+	// returns, e.g. "www.example.com." for "www" or unquoted TXT content.
+	if data.Record.IsNull() {
+		data.Record = types.StringValue(record)
+	}
+	if data.Type.IsNull() || !strings.EqualFold(data.Type.ValueString(), value.Type) {
+		data.Type = types.StringValue(value.Type)
+	}
+	if data.Content.IsNull() || helpers.NormalizeDNSContent(value.Type, data.Content.ValueString()) != helpers.NormalizeDNSContent(value.Type, value.Content) {
+		data.Content = types.StringValue(value.Content)
+	}
+	data.TTL = types.Int64Value(int64(value.TTL))
 	// Other fields like Priority, Weight, etc., are not in the provided struct, so not populated in Read
-	data.ContentID = types.StringValue(foundContent.ID)
-	data.Status = types.StringValue(foundRecord.Status)
-	data.IsProtected = types.BoolValue(foundRecord.IsProtected)
+	data.ContentID = types.StringValue(value.ContentID)
+	data.Status = types.StringValue(value.Status)
+	data.IsProtected = types.BoolValue(value.Protected)
+	if data.AllowOverwrite.IsNull() {
+		data.AllowOverwrite = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -330,7 +367,7 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	contentID := parts[3]
 
 	// Extract values
-	content := data.Content.ValueString()
+	content := helpers.DNSAPIContent(recordType, data.Content.ValueString())
 	ttl := int(data.TTL.ValueInt64())
 	priority := int(data.Priority.ValueInt64())
 	weight := int(data.Weight.ValueInt64())
@@ -349,28 +386,14 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Fetch the record to get status and is_protected
-	latestRecordsResp, err := r.client.GetRecords(domain)
+	value, err := r.findValue(domain, record, recordType, contentID)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to fetch records after operation, got error: %s", err))
 		return
 	}
-
-	// Find the record
-	for _, rec := range latestRecordsResp.Data {
-		recordFull := record
-		if !strings.Contains(record, domain) {
-			recordFull = record + "." + domain
-		}
-		if (rec.Name == record || rec.Name == recordFull) && rec.Type == recordType {
-			for _, c := range rec.Content {
-				if c.ID == contentID {
-					data.Status = types.StringValue(rec.Status)
-					data.IsProtected = types.BoolValue(rec.IsProtected)
-					break
-				}
-			}
-			break
-		}
+	if value != nil {
+		data.Status = types.StringValue(value.Status)
+		data.IsProtected = types.BoolValue(value.Protected)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -404,3 +427,39 @@ func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 }
+
+func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if len(strings.Split(req.ID, "/")) != 4 {
+		resp.Diagnostics.AddError("Invalid Import ID", "Expected an ID of the form domain/record/type/contentId.")
+		return
+	}
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// findValue returns the value with the given content ID, or nil if it no
+// longer exists.
+func (r *dnsRecordResource) findValue(domain, record, recordType, contentID string) (*helpers.DNSRecordValue, error) {
+	recordsResp, err := r.client.GetRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range helpers.FindDNSRecordValues(recordsResp.Data, record, recordType, domain) {
+		if value.ContentID == contentID {
+			return &value, nil
+		}
+	}
+	return nil, nil
+}
+
+// dnsRecordNameChanged requires replacement only when the record name changes
+// to a different name, not to an equivalent spelling such as an FQDN.
+func dnsRecordNameChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var domain types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if domain.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+	resp.RequiresReplace = helpers.NormalizeDNSRecordName(req.StateValue.ValueString(), domain.ValueString()) !=
+		helpers.NormalizeDNSRecordName(req.PlanValue.ValueString(), domain.ValueString())
+}
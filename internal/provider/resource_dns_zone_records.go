@@ -0,0 +1,592 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the resource interfaces.
+var _ resource.Resource = &dnsZoneRecordsResource{}
+var _ resource.ResourceWithImportState = &dnsZoneRecordsResource{}
+var _ resource.ResourceWithModifyPlan = &dnsZoneRecordsResource{}
+var _ resource.ResourceWithValidateConfig = &dnsZoneRecordsResource{}
+
+func NewDnsZoneRecordsResource(mutex *sync.Mutex) resource.Resource {
+	return &dnsZoneRecordsResource{mutex: mutex}
+}
+
+type dnsZoneRecordsResource struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+// liveDNSRecord is one content entry of a record as returned by GetRecords.
+type liveDNSRecord struct {
+	APIName   string
+	Name      string
+	Type      string
+	Content   string
+	TTL       int
+	ContentID string
+	Protected bool
+}
+
+func (r *dnsZoneRecordsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_records"
+}
+
+func (r *dnsZoneRecordsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manages every record of a DNS domain. Records added outside Terraform show up as drift and are removed on the next apply, " +
+			"except for names matching `ignore` and protected records, which are never deleted. Do not combine with `virakcloud_dns_record` on the same domain. " +
+			"Import using the domain name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The domain name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain whose records are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The complete set of records. Each content value of a multi-value record is its own entry.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"record": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The record name relative to the domain, e.g. `www`; `@` for the apex.",
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The record type (A, MX, SRV, etc.).",
+						},
+						"content": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The record content.",
+						},
+						"ttl": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: fmt.Sprintf("Time to live (TTL) for the record. Defaults to %d.", helpers.DefaultDNSRecordTTL),
+						},
+						"priority": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Priority for MX and SRV records.",
+						},
+						"weight": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Weight for SRV records.",
+						},
+						"port": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Port for SRV records.",
+						},
+						"flags": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Flags for CAA records.",
+						},
+						"tag": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Tag for CAA records.",
+						},
+						"license": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "License for TLSA records.",
+						},
+						"choicer": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Choicer for TLSA records.",
+						},
+						"match": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Match for TLSA records.",
+						},
+					},
+				},
+			},
+			"ignore": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Glob patterns of relative record names managed elsewhere, e.g. `_acme-challenge*`. Matching records are neither read nor changed.",
+			},
+			"content_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "API content ID of each record, keyed by `<record>/<type>/<content>`.",
+			},
+		},
+	}
+}
+
+func (r *dnsZoneRecordsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *dnsZoneRecordsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Records.IsNull() || data.Records.IsUnknown() || data.Domain.IsUnknown() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+	var ignore []string
+	if !data.Ignore.IsNull() && !data.Ignore.IsUnknown() {
+		resp.Diagnostics.Append(data.Ignore.ElementsAs(ctx, &ignore, false)...)
+	}
+
+	seen := map[string]bool{}
+	for _, elem := range data.Records.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var rec models.DnsZoneRecordModel
+		resp.Diagnostics.Append(obj.As(ctx, &rec, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if rec.Record.IsUnknown() || rec.Type.IsUnknown() || rec.Content.IsUnknown() {
+			continue
+		}
+
+		helpers.ValidateDNSRecordConfig(rec, domain, path.Root("records").AtSetValue(obj), &resp.Diagnostics)
+
+		recordPath := path.Root("records").AtSetValue(obj).AtName("record")
+		name := rec.Record.ValueString()
+		relative := helpers.NormalizeDNSRecordName(name, domain)
+		if name != relative {
+			resp.Diagnostics.AddAttributeError(recordPath, "Invalid Record Name",
+				fmt.Sprintf("Record names must be relative to the domain in lower case; use %q instead of %q.", relative, name))
+			continue
+		}
+		if helpers.MatchesDNSIgnore(relative, ignore) {
+			resp.Diagnostics.AddAttributeError(recordPath, "Ignored Record Declared",
+				fmt.Sprintf("Record %q matches an ignore pattern, so it would never be managed.", name))
+			continue
+		}
+
+		key := helpers.DNSRecordKey(name, rec.Type.ValueString(), rec.Content.ValueString(), domain)
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(recordPath, "Duplicate Record",
+				fmt.Sprintf("%s %s %q is declared more than once.", name, rec.Type.ValueString(), rec.Content.ValueString()))
+		}
+		seen[key] = true
+	}
+}
+
+func (r *dnsZoneRecordsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Domain.Equal(state.Domain) && plan.Records.Equal(state.Records) && plan.Ignore.Equal(state.Ignore) {
+		plan.ContentIDs = state.ContentIDs
+	} else {
+		plan.ContentIDs = types.MapUnknown(types.StringType)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *dnsZoneRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Domain
+	r.converge(ctx, &data, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dnsZoneRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+	live, err := r.liveRecords(ctx, domain, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s, got error: %s", domain, err))
+		return
+	}
+
+	prior := r.recordsByKey(ctx, domain, data.Records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records := make([]models.DnsZoneRecordModel, 0, len(live))
+	seen := map[string]bool{}
+	for _, rec := range live {
+		key := helpers.DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		declared, ok := prior[key]
+		if !ok {
+			if rec.Protected {
+				// Protected records such as the apex SOA and NS are only
+				// tracked when declared.
+				continue
+			}
+			log.Printf("Record %s %s %q is not managed by Terraform", rec.Name, rec.Type, rec.Content)
+			records = append(records, models.DnsZoneRecordModel{
+				Record:   types.StringValue(rec.Name),
+				Type:     types.StringValue(rec.Type),
+				Content:  types.StringValue(rec.Content),
+				TTL:      types.Int64Value(int64(rec.TTL)),
+				Priority: types.Int64Null(),
+				Weight:   types.Int64Null(),
+				Port:     types.Int64Null(),
+				Flags:    types.Int64Null(),
+				Tag:      types.StringNull(),
+				License:  types.Int64Null(),
+				Choicer:  types.Int64Null(),
+				Match:    types.Int64Null(),
+			})
+			continue
+		}
+
+		// The API does not return type-specific fields, so keep the declared
+		// spelling and values and only refresh the TTL.
+		if declared.TTL.IsNull() && rec.TTL != helpers.DefaultDNSRecordTTL || !declared.TTL.IsNull() && declared.TTL.ValueInt64() != int64(rec.TTL) {
+			declared.TTL = types.Int64Value(int64(rec.TTL))
+		}
+		records = append(records, declared)
+	}
+
+	recordsSet, diags := types.SetValueFrom(ctx, helpers.GetDnsZoneRecordObjectType(), records)
+	resp.Diagnostics.Append(diags...)
+	data.Records = recordsSet
+	data.ContentIDs = r.contentIDs(ctx, domain, records, live, &resp.Diagnostics)
+	data.ID = data.Domain
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dnsZoneRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan, state models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := r.recordsByKey(ctx, state.Domain.ValueString(), state.Records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Domain
+	r.converge(ctx, &plan, prior, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dnsZoneRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.DnsZoneRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only remove the records this resource declares; records that drifted
+	// in since the last refresh are left alone.
+	domain := data.Domain.ValueString()
+	declared := r.recordsByKey(ctx, domain, data.Records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live, err := r.liveRecords(ctx, domain, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s, got error: %s", domain, err))
+		return
+	}
+
+	for _, rec := range live {
+		if _, ok := declared[helpers.DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)]; !ok {
+			continue
+		}
+		if rec.Protected {
+			resp.Diagnostics.AddWarning("Protected DNS Record Kept", fmt.Sprintf("Record %s %s %q is protected and was not deleted.", rec.Name, rec.Type, rec.Content))
+			continue
+		}
+		if _, err := r.client.DeleteRecord(domain, rec.APIName, rec.Type, rec.ContentID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete DNS record %s %s %q, got error: %s", rec.Name, rec.Type, rec.Content, err))
+			return
+		}
+	}
+}
+
+func (r *dnsZoneRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), req.ID)...)
+}
+
+// converge applies the minimal set of API calls that turns the domain's live
+// records into data.Records. prior holds the previously applied records by
+// key, used to detect changes to fields the API does not return.
+func (r *dnsZoneRecordsResource) converge(ctx context.Context, data *models.DnsZoneRecordsResourceModel, prior map[string]models.DnsZoneRecordModel, diags *diag.Diagnostics) {
+	domain := data.Domain.ValueString()
+
+	var desired []models.DnsZoneRecordModel
+	diags.Append(data.Records.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	live, err := r.liveRecords(ctx, domain, data)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s, got error: %s", domain, err))
+		return
+	}
+
+	liveByKey := map[string]liveDNSRecord{}
+	for _, rec := range live {
+		key := helpers.DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)
+		if _, ok := liveByKey[key]; !ok {
+			liveByKey[key] = rec
+		}
+	}
+
+	// Values that exist on both sides only need an update if a field changed.
+	matched := map[string]bool{}
+	var toCreate []models.DnsZoneRecordModel
+	for _, rec := range desired {
+		key := helpers.DNSRecordKey(rec.Record.ValueString(), rec.Type.ValueString(), rec.Content.ValueString(), domain)
+		current, ok := liveByKey[key]
+		if !ok {
+			toCreate = append(toCreate, rec)
+			continue
+		}
+		matched[key] = true
+
+		previous, known := prior[key]
+		if known && dnsZoneRecordTTL(rec) == int64(current.TTL) && dnsZoneRecordFieldsEqual(rec, previous) {
+			continue
+		}
+		log.Printf("Updating DNS record %s %s %q", current.Name, current.Type, current.Content)
+		if err := r.updateRecord(domain, current, rec); err != nil {
+			diags.AddError("Client Error", err.Error())
+			return
+		}
+	}
+
+	// Remaining live values of the same name and type are rewritten in place
+	// before anything is created or deleted.
+	stale := map[string][]liveDNSRecord{}
+	for _, rec := range live {
+		key := helpers.DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)
+		if matched[key] || rec.Protected {
+			continue
+		}
+		matched[key] = true
+		group := rec.Name + "/" + strings.ToUpper(rec.Type)
+		stale[group] = append(stale[group], rec)
+	}
+
+	for _, rec := range toCreate {
+		group := helpers.NormalizeDNSRecordName(rec.Record.ValueString(), domain) + "/" + strings.ToUpper(rec.Type.ValueString())
+		if candidates := stale[group]; len(candidates) > 0 {
+			current := candidates[0]
+			stale[group] = candidates[1:]
+			log.Printf("Replacing DNS record content %s %s %q with %q", current.Name, current.Type, current.Content, rec.Content.ValueString())
+			if err := r.updateRecord(domain, current, rec); err != nil {
+				diags.AddError("Client Error", err.Error())
+				return
+			}
+			continue
+		}
+
+		log.Printf("Creating DNS record %s %s %q", rec.Record.ValueString(), rec.Type.ValueString(), rec.Content.ValueString())
+		_, err := r.client.CreateRecord(domain, rec.Record.ValueString(), rec.Type.ValueString(), helpers.DNSAPIContent(rec.Type.ValueString(), rec.Content.ValueString()),
+			int(dnsZoneRecordTTL(rec)), int(rec.Priority.ValueInt64()), int(rec.Weight.ValueInt64()), int(rec.Port.ValueInt64()),
+			int(rec.Flags.ValueInt64()), rec.Tag.ValueString(), int(rec.License.ValueInt64()), int(rec.Choicer.ValueInt64()), int(rec.Match.ValueInt64()))
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to create DNS record %s %s %q, got error: %s", rec.Record.ValueString(), rec.Type.ValueString(), rec.Content.ValueString(), err))
+			return
+		}
+	}
+
+	groups := make([]string, 0, len(stale))
+	for group := range stale {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		for _, rec := range stale[group] {
+			log.Printf("Deleting DNS record %s %s %q", rec.Name, rec.Type, rec.Content)
+			if _, err := r.client.DeleteRecord(domain, rec.APIName, rec.Type, rec.ContentID); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to delete DNS record %s %s %q, got error: %s", rec.Name, rec.Type, rec.Content, err))
+				return
+			}
+		}
+	}
+
+	for key, rec := range liveByKey {
+		if rec.Protected && !matched[key] {
+			log.Printf("Keeping protected DNS record %s %s %q", rec.Name, rec.Type, rec.Content)
+		}
+	}
+
+	refreshed, err := r.liveRecords(ctx, domain, data)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read DNS records of %s after applying changes, got error: %s", domain, err))
+		return
+	}
+	data.ContentIDs = r.contentIDs(ctx, domain, desired, refreshed, diags)
+}
+
+func (r *dnsZoneRecordsResource) updateRecord(domain string, current liveDNSRecord, rec models.DnsZoneRecordModel) error {
+	_, err := r.client.UpdateRecord(domain, current.APIName, current.Type, current.ContentID, helpers.DNSAPIContent(rec.Type.ValueString(), rec.Content.ValueString()),
+		int(dnsZoneRecordTTL(rec)), int(rec.Priority.ValueInt64()), int(rec.Weight.ValueInt64()), int(rec.Port.ValueInt64()),
+		int(rec.Flags.ValueInt64()), rec.Tag.ValueString(), int(rec.License.ValueInt64()), int(rec.Choicer.ValueInt64()), int(rec.Match.ValueInt64()))
+	if err != nil {
+		return fmt.Errorf("unable to update DNS record %s %s %q, got error: %s", current.Name, current.Type, current.Content, err)
+	}
+	return nil
+}
+
+// liveRecords returns the domain's records, one entry per content value,
+// without names matching data.Ignore.
+func (r *dnsZoneRecordsResource) liveRecords(ctx context.Context, domain string, data *models.DnsZoneRecordsResourceModel) ([]liveDNSRecord, error) {
+	var ignore []string
+	if !data.Ignore.IsNull() && !data.Ignore.IsUnknown() {
+		data.Ignore.ElementsAs(ctx, &ignore, false)
+	}
+
+	recordsResp, err := r.client.GetRecords(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var live []liveDNSRecord
+	for _, rec := range recordsResp.Data {
+		name := helpers.NormalizeDNSRecordName(rec.Name, domain)
+		if helpers.MatchesDNSIgnore(name, ignore) {
+			continue
+		}
+		for _, c := range rec.Content {
+			live = append(live, liveDNSRecord{
+				APIName:   rec.Name,
+				Name:      name,
+				Type:      strings.ToUpper(rec.Type),
+				Content:   c.ContentRaw,
+				TTL:       rec.TTL,
+				ContentID: c.ID,
+				Protected: rec.IsProtected,
+			})
+		}
+	}
+	return live, nil
+}
+
+func (r *dnsZoneRecordsResource) recordsByKey(ctx context.Context, domain string, records types.Set, diags *diag.Diagnostics) map[string]models.DnsZoneRecordModel {
+	byKey := map[string]models.DnsZoneRecordModel{}
+	if records.IsNull() || records.IsUnknown() {
+		return byKey
+	}
+	var list []models.DnsZoneRecordModel
+	diags.Append(records.ElementsAs(ctx, &list, false)...)
+	for _, rec := range list {
+		byKey[helpers.DNSRecordKey(rec.Record.ValueString(), rec.Type.ValueString(), rec.Content.ValueString(), domain)] = rec
+	}
+	return byKey
+}
+
+// contentIDs maps each record's "<record>/<type>/<content>" to the ID of the
+// live content value it corresponds to.
+func (r *dnsZoneRecordsResource) contentIDs(ctx context.Context, domain string, records []models.DnsZoneRecordModel, live []liveDNSRecord, diags *diag.Diagnostics) types.Map {
+	liveIDs := map[string]string{}
+	for _, rec := range live {
+		liveIDs[helpers.DNSRecordKey(rec.Name, rec.Type, rec.Content, domain)] = rec.ContentID
+	}
+
+	ids := map[string]string{}
+	for _, rec := range records {
+		key := helpers.DNSRecordKey(rec.Record.ValueString(), rec.Type.ValueString(), rec.Content.ValueString(), domain)
+		if id, ok := liveIDs[key]; ok {
+			ids[rec.Record.ValueString()+"/"+rec.Type.ValueString()+"/"+rec.Content.ValueString()] = id
+		}
+	}
+
+	value, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	return value
+}
+
+func dnsZoneRecordTTL(rec models.DnsZoneRecordModel) int64 {
+	if rec.TTL.IsNull() || rec.TTL.IsUnknown() {
+		return helpers.DefaultDNSRecordTTL
+	}
+	return rec.TTL.ValueInt64()
+}
+
+// dnsZoneRecordFieldsEqual compares the type-specific fields, which the API
+// does not return.
+func dnsZoneRecordFieldsEqual(a, b models.DnsZoneRecordModel) bool {
+	return a.Priority.Equal(b.Priority) && a.Weight.Equal(b.Weight) && a.Port.Equal(b.Port) &&
+		a.Flags.Equal(b.Flags) && a.Tag.Equal(b.Tag) && a.License.Equal(b.License) &&
+		a.Choicer.Equal(b.Choicer) && a.Match.Equal(b.Match)
+}
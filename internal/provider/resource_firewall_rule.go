@@ -5,17 +5,24 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 // Ensure the implementation satisfies the resource.Resource interface.
 var _ resource.Resource = &firewallRuleResource{}
+var _ resource.ResourceWithValidateConfig = &firewallRuleResource{}
 
 func NewFirewallRuleResource(mutex *sync.Mutex) resource.Resource {
 	return &firewallRuleResource{mutex: mutex}
@@ -61,6 +68,9 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallIPv4, helpers.FirewallIPv6),
+				},
 			},
 			"traffic_type": schema.StringAttribute{
 				Required:            true,
@@ -68,6 +78,9 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallTrafficIngress, helpers.FirewallTrafficEgress),
+				},
 			},
 			"protocol": schema.StringAttribute{
 				Required:            true,
@@ -75,47 +88,58 @@ func (r *firewallRuleResource) Schema(ctx context.Context, req resource.SchemaRe
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallProtocolTCP, helpers.FirewallProtocolUDP, helpers.FirewallProtocolICMP),
+				},
 			},
 			"ip_source": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Source IP address or CIDR.",
+				MarkdownDescription: "Source IP address or CIDR. Must match `ip_version`; a host address is treated as a /32 (or /128) block.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(firewallRuleCIDRChanged,
+						"Changing the address to a different network forces replacement.",
+						"Changing the address to a different network forces replacement."),
 				},
 			},
 			"ip_destination": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Destination IP address or CIDR.",
+				MarkdownDescription: "Destination IP address or CIDR. Must match `ip_version`; a host address is treated as a /32 (or /128) block.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.RequiresReplaceIf(firewallRuleCIDRChanged,
+						"Changing the address to a different network forces replacement.",
+						"Changing the address to a different network forces replacement."),
 				},
 			},
 			"start_port": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "Start port (for TCP/UDP protocols).",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"end_port": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "End port (for TCP/UDP protocols).",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"icmp_code": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "ICMP code (for ICMP protocol).",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				Validators:          []validator.Int64{int64validator.Between(-1, 255)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"icmp_type": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "ICMP type (for ICMP protocol).",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				Validators:          []validator.Int64{int64validator.Between(-1, 255)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"public_ip_id": schema.StringAttribute{
@@ -171,8 +195,8 @@ func (r *firewallRuleResource) Create(ctx context.Context, req resource.CreateRe
 	body := map[string]interface{}{
 		"traffic_type":   data.TrafficType.ValueString(),
 		"protocol_type":  data.Protocol.ValueString(),
-		"ip_source":      data.IPSource.ValueString(),
-		"ip_destination": data.IPDestination.ValueString(),
+		"ip_source":      helpers.NormalizeCIDR(data.IPSource.ValueString()),
+		"ip_destination": helpers.NormalizeCIDR(data.IPDestination.ValueString()),
 	}
 
 	// Add protocol-specific fields
@@ -311,6 +335,7 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 			if rule.ID == ruleID {
 				data.Status = types.StringValue(rule.Status)
 				data.CreatedAt = types.StringValue(fmt.Sprintf("%d", rule.CreatedAt))
+				refreshFirewallRuleAddresses(&data, rule.IPSource, rule.IPDestination)
 				break
 			}
 		}
@@ -324,6 +349,7 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 			if rule.ID == ruleID {
 				data.Status = types.StringValue(rule.Status)
 				data.CreatedAt = types.StringValue(fmt.Sprintf("%d", rule.CreatedAt))
+				refreshFirewallRuleAddresses(&data, rule.IPSource, rule.IPDestination)
 				break
 			}
 		}
@@ -333,11 +359,27 @@ func (r *firewallRuleResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *firewallRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Firewall rules don't support updates - they must be recreated
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Firewall rule resources do not support updates. To change a firewall rule, delete and recreate it.",
-	)
+	var plan, state models.FirewallRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute requires replacement, so the only in-place change
+	// is respelling ip_source or ip_destination as an equivalent address, e.g.
+	// "10.0.0.1" for "10.0.0.1/32". The rule itself is unchanged.
+
+	plan.ID = state.ID
+	plan.Status = state.Status
+	plan.CreatedAt = state.CreatedAt
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// firewallRuleCIDRChanged requires replacement only when an address changes
+// to a different network, not to an equivalent spelling.
+func firewallRuleCIDRChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !helpers.EquivalentCIDR(req.StateValue.ValueString(), req.PlanValue.ValueString())
 }
 
 func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -390,3 +432,36 @@ func (r *firewallRuleResource) Delete(ctx context.Context, req resource.DeleteRe
 		}
 	}
 }
+
+func (r *firewallRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.FirewallRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	helpers.ValidateFirewallRuleConfig(models.FirewallRulesetRuleModel{
+		IPVersion:     data.IPVersion,
+		TrafficType:   data.TrafficType,
+		Protocol:      data.Protocol,
+		IPSource:      data.IPSource,
+		IPDestination: data.IPDestination,
+		StartPort:     data.StartPort,
+		EndPort:       data.EndPort,
+		ICMPCode:      data.ICMPCode,
+		ICMPType:      data.ICMPType,
+		PublicIPID:    data.PublicIPID,
+	}, path.Empty(), &resp.Diagnostics)
+}
+
+// refreshFirewallRuleAddresses records the API's addresses only when they
+// differ from state after normalisation, so "10.0.0.1" and "10.0.0.1/32" do
+// not show up as drift.
+func refreshFirewallRuleAddresses(data *models.FirewallRuleResourceModel, ipSource, ipDestination string) {
+	if !helpers.EquivalentCIDR(data.IPSource.ValueString(), ipSource) {
+		data.IPSource = types.StringValue(ipSource)
+	}
+	if !helpers.EquivalentCIDR(data.IPDestination.ValueString(), ipDestination) {
+		data.IPDestination = types.StringValue(ipDestination)
+	}
+}
@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the resource interfaces.
+var _ resource.Resource = &firewallRulesetResource{}
+var _ resource.ResourceWithImportState = &firewallRulesetResource{}
+var _ resource.ResourceWithValidateConfig = &firewallRulesetResource{}
+
+func NewFirewallRulesetResource(mutex *sync.Mutex) resource.Resource {
+	return &firewallRulesetResource{mutex: mutex}
+}
+
+type firewallRulesetResource struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+func (r *firewallRulesetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_ruleset"
+}
+
+func (r *firewallRulesetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manages every IPv4 and IPv6 firewall rule on a Virak Cloud network. " +
+			"Rules on the network that are not declared here, including rules created in the panel, are removed on apply. " +
+			"Do not combine with `virakcloud_firewall_rule` on the same network. " +
+			"Import using `<zone_id>/<network_id>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Firewall ruleset ID (the network ID).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The complete set of firewall rules for the network. An empty set removes all rules.",
+				NestedObject:        firewallRuleNestedObject(),
+			},
+		},
+	}
+}
+
+func (r *firewallRulesetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *firewallRulesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.FirewallRulesetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed := r.converge(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(removed) > 0 {
+		descriptions := make([]string, 0, len(removed))
+		for _, rule := range removed {
+			descriptions = append(descriptions, rule.String())
+		}
+		resp.Diagnostics.AddWarning(
+			"Unmanaged Firewall Rules Removed",
+			fmt.Sprintf("The following rules on network %s were not declared in the ruleset and have been removed:\n  - %s", data.NetworkID.ValueString(), strings.Join(descriptions, "\n  - ")),
+		)
+	}
+
+	data.ID = data.NetworkID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *firewallRulesetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data models.FirewallRulesetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := helpers.ListFirewallRules(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString())
+	if err != nil {
+		if gone, _ := helpers.NetworkNotFound(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString()); gone {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		helpers.HandleAPIError(&resp.Diagnostics, "Client Error", fmt.Errorf("unable to list firewall rules: %w", err))
+		return
+	}
+
+	// Keep the configured spelling of rules that are equivalent to what the
+	// API reports, e.g. "10.0.0.1" for "10.0.0.1/32".
+	prior := firewallRulesFromSet(ctx, data.Rules, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	priorByKey := make(map[string]helpers.FirewallRule, len(prior))
+	for _, rule := range prior {
+		priorByKey[rule.Key()] = rule
+	}
+	for i, rule := range existing {
+		if match, ok := priorByKey[rule.Key()]; ok {
+			existing[i].IPSource = match.IPSource
+			existing[i].IPDestination = match.IPDestination
+		}
+	}
+
+	data.ID = data.NetworkID
+	data.Rules = helpers.CreateFirewallRulesSet(existing, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *firewallRulesetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan models.FirewallRulesetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.converge(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.NetworkID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *firewallRulesetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.FirewallRulesetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	networkID := data.NetworkID.ValueString()
+
+	declared := firewallRulesFromSet(ctx, data.Rules, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := helpers.ListFirewallRules(r.client, zoneID, networkID)
+	if err != nil {
+		helpers.HandleAPIError(&resp.Diagnostics, "Client Error", err)
+		return
+	}
+
+	// Only the rules declared in state are removed; anything added after the
+	// last apply is left in place.
+	_, unmanaged := helpers.DiffFirewallRules(declared, existing)
+	unmanagedIDs := make(map[string]struct{}, len(unmanaged))
+	for _, rule := range unmanaged {
+		unmanagedIDs[rule.ID] = struct{}{}
+	}
+
+	for _, rule := range existing {
+		if _, skip := unmanagedIDs[rule.ID]; skip {
+			continue
+		}
+		if err := helpers.DeleteFirewallRule(r.client, zoneID, networkID, rule); err != nil {
+			resp.Diagnostics.AddError("Firewall Rule Deletion Failed", err.Error())
+			return
+		}
+	}
+}
+
+func (r *firewallRulesetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.FirewallRulesetResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateFirewallRuleSet(ctx, data.Rules, path.Root("rules"), &resp.Diagnostics)
+}
+
+func (r *firewallRulesetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zoneID, networkID, ok := strings.Cut(req.ID, "/")
+	if !ok || zoneID == "" || networkID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format '<zone_id>/<network_id>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), networkID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), networkID)...)
+}
+
+// converge creates missing rules and removes every other rule on the network,
+// in the order DiffFirewallRules describes. It returns the rules that were
+// removed.
+func (r *firewallRulesetResource) converge(ctx context.Context, data *models.FirewallRulesetResourceModel, diags *diag.Diagnostics) []helpers.FirewallRule {
+	zoneID := data.ZoneID.ValueString()
+	networkID := data.NetworkID.ValueString()
+
+	desired := firewallRulesFromSet(ctx, data.Rules, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	existing, err := helpers.ListFirewallRules(r.client, zoneID, networkID)
+	if err != nil {
+		helpers.HandleAPIError(diags, "Client Error", err)
+		return nil
+	}
+
+	toCreate, toDelete := helpers.DiffFirewallRules(desired, existing)
+	helpers.LogInfo("Firewall ruleset for network %s: %d rule(s) to create, %d rule(s) to delete", networkID, len(toCreate), len(toDelete))
+
+	for _, rule := range toCreate {
+		ruleID, err := helpers.CreateFirewallRule(r.client, zoneID, networkID, rule)
+		if err != nil {
+			diags.AddError("Firewall Rule Creation Failed", err.Error())
+			return nil
+		}
+		helpers.LogInfo("✓ Created firewall rule %s (%s)", rule, ruleID)
+	}
+
+	for _, rule := range toDelete {
+		if err := helpers.DeleteFirewallRule(r.client, zoneID, networkID, rule); err != nil {
+			diags.AddError("Firewall Rule Deletion Failed", err.Error())
+			return nil
+		}
+		helpers.LogInfo("✓ Deleted firewall rule %s", rule)
+	}
+
+	return toDelete
+}
+
+func firewallRulesFromSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) []helpers.FirewallRule {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var ruleModels []models.FirewallRulesetRuleModel
+	diags.Append(set.ElementsAs(ctx, &ruleModels, false)...)
+	return helpers.FirewallRulesFromModels(ruleModels)
+}
+
+// firewallRuleNestedObject is the rule schema shared by firewall rulesets,
+// security groups and security group bindings.
+func firewallRuleNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"ip_version": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "IP version (ipv4 or ipv6).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallIPv4, helpers.FirewallIPv6),
+				},
+			},
+			"traffic_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Traffic type (Ingress or Egress).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallTrafficIngress, helpers.FirewallTrafficEgress),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Protocol (TCP, UDP, or ICMP).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.FirewallProtocolTCP, helpers.FirewallProtocolUDP, helpers.FirewallProtocolICMP),
+				},
+			},
+			"ip_source": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Source IP address or CIDR.",
+			},
+			"ip_destination": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Destination IP address or CIDR.",
+			},
+			"start_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Start port (for TCP/UDP protocols).",
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"end_port": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "End port (for TCP/UDP protocols).",
+				Validators:          []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"icmp_code": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "ICMP code (for ICMP protocol).",
+				Validators:          []validator.Int64{int64validator.Between(-1, 255)},
+			},
+			"icmp_type": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "ICMP type (for ICMP protocol).",
+				Validators:          []validator.Int64{int64validator.Between(-1, 255)},
+			},
+			"public_ip_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Public IP ID (for IPv4 ingress rules).",
+			},
+		},
+	}
+}
+
+// validateFirewallRuleSet runs ValidateFirewallRuleConfig on every known rule
+// in a set of rule objects.
+func validateFirewallRuleSet(ctx context.Context, set types.Set, attrPath path.Path, diags *diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return
+	}
+
+	for _, elem := range set.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var rule models.FirewallRulesetRuleModel
+		diags.Append(obj.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return
+		}
+		helpers.ValidateFirewallRuleConfig(rule, attrPath.AtSetValue(obj), diags)
+	}
+}
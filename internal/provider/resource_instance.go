@@ -3,12 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
@@ -121,7 +128,58 @@ func (r *instanceResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 			"desired_state": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Desired state of the instance. Valid values: 'running', 'stopped'. Setting this will trigger start/stop operations. Use 'reboot' to restart a running instance.",
+				MarkdownDescription: "Desired state of the instance. Valid values: 'running', 'stopped'. Setting this will trigger start/stop operations. Use `reboot_trigger` to restart a running instance.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("running", "stopped"),
+				},
+			},
+			"delete_strategy": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Controls the steps taken when the instance is destroyed. Omitting the block keeps the default behaviour: boot the instance, detach its volumes, disconnect its networks, delete it and wait until it is gone. Every step is logged; non-default or failed steps are also reported in a warning diagnostic.",
+				Attributes: map[string]schema.Attribute{
+					"boot_before_delete": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Start a stopped instance before deleting it so volumes and networks can be detached cleanly. Defaults to `true`.",
+					},
+					"volumes": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "What to do with attached volumes. Valid values: 'detach' (default) detaches them and keeps them, 'delete' detaches and deletes them, 'none' leaves them attached for the platform to handle.",
+						Validators: []validator.String{
+							stringvalidator.OneOf(helpers.DeleteVolumesDetach, helpers.DeleteVolumesDelete, helpers.DeleteVolumesNone),
+						},
+					},
+					"stop_before_delete": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Stop the instance using `stop_mode` and `graceful_stop_timeout` after detaching volumes and networks, before deleting it. Defaults to `false`.",
+					},
+					"wait_for_expunge": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Wait until the instance no longer appears in the zone before completing. The API removes instances immediately and has no separate expunge call; set to `false` to return as soon as the delete request is accepted. Defaults to `true`.",
+					},
+				},
+			},
+			"reboot_trigger": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary key/value pairs that reboot the instance whenever they change, e.g. a hash of a pushed configuration. The instance is not rebooted on creation, when the map is removed, or when it is not running.",
+			},
+			"stop_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(helpers.StopModeGraceful),
+				MarkdownDescription: "How the instance is stopped for `desired_state = \"stopped\"` and image rebuilds. Valid values: 'graceful' (default), 'force', 'graceful_then_force'. With 'graceful_then_force' the instance is force stopped once `graceful_stop_timeout` elapses.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.StopModeGraceful, helpers.StopModeForce, helpers.StopModeGracefulThenForce),
+				},
+			},
+			"graceful_stop_timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(int64(helpers.DefaultGracefulStopTimeout.Seconds())),
+				MarkdownDescription: "Seconds to wait for a graceful stop before failing ('graceful') or forcing the stop ('graceful_then_force'). Defaults to 600.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 		},
 	}
@@ -251,9 +309,10 @@ func (r *instanceResource) Update(ctx context.Context, req resource.UpdateReques
 	plan.Username = state.Username
 	imageChanged := !plan.VMImageID.Equal(state.VMImageID)
 	desiredStateChanged := !plan.DesiredState.IsNull() && !plan.DesiredState.Equal(state.DesiredState)
+	rebootTriggered := rebootTriggerChanged(plan.RebootTrigger, state.RebootTrigger)
 
 	if imageChanged {
-		if err := helpers.EnsureInstanceStopped(r.client, plan.ZoneID.ValueString(), plan.ID.ValueString(), &resp.Diagnostics); err != nil {
+		if err := helpers.EnsureInstanceStopped(r.client, plan.ZoneID.ValueString(), plan.ID.ValueString(), helpers.NewStopPolicy(plan.StopMode, plan.GracefulStopTimeout), &resp.Diagnostics); err != nil {
 			return
 		}
 		_, err := r.client.RebuildInstance(
@@ -290,7 +349,7 @@ func (r *instanceResource) Update(ctx context.Context, req resource.UpdateReques
 		} else if !state.Status.IsNull() && !state.Status.IsUnknown() {
 			currentStatus = state.Status.ValueString()
 		}
-		result := helpers.HandleInstanceLifecycle(r.client, plan.ZoneID.ValueString(), plan.ID.ValueString(), plan.DesiredState.ValueString(), currentStatus)
+		result := helpers.HandleInstanceLifecycle(r.client, plan.ZoneID.ValueString(), plan.ID.ValueString(), plan.DesiredState.ValueString(), currentStatus, helpers.NewStopPolicy(plan.StopMode, plan.GracefulStopTimeout))
 		resp.Diagnostics.Append(result.Diags...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -312,6 +371,23 @@ func (r *instanceResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 	}
 
+	if rebootTriggered {
+		currentStatus := ""
+		if !plan.Status.IsNull() && !plan.Status.IsUnknown() && plan.Status.ValueString() != "" {
+			currentStatus = plan.Status.ValueString()
+		} else if !state.Status.IsNull() && !state.Status.IsUnknown() {
+			currentStatus = state.Status.ValueString()
+		}
+		result := helpers.HandleInstanceReboot(r.client, plan.ZoneID.ValueString(), plan.ID.ValueString(), currentStatus)
+		resp.Diagnostics.Append(result.Diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !result.Status.IsNull() {
+			plan.Status = result.Status
+		}
+	}
+
 	// Handle network changes
 	// Build network ID maps from plan and state
 	// IMPORTANT: stateNetworkIDs should ONLY include explicitly managed networks from state.NetworkIDs
@@ -411,16 +487,62 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	if err := helpers.EnsureInstanceRunning(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics); err != nil {
+	strategy := helpers.NewDeleteStrategy(ctx, data.DeleteStrategy, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	var report helpers.DeleteStepReport
+	defer func() {
+		if report.Notable() {
+			resp.Diagnostics.AddWarning("Instance Delete Steps", fmt.Sprintf("Steps performed while deleting instance '%s':\n%s", data.ID.ValueString(), report.String()))
+		}
+	}()
 
-	helpers.DetachAllVolumes(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics)
+	if strategy.BootBeforeDelete {
+		if err := helpers.EnsureInstanceRunning(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics); err != nil {
+			report.AddNotable("booting instance before delete failed")
+			return
+		}
+		report.Add("ensured instance is running before delete")
+	} else {
+		report.AddNotable("skipped booting instance before delete")
+	}
+
+	switch strategy.Volumes {
+	case helpers.DeleteVolumesDelete:
+		deleted, failed := helpers.DeleteAllVolumes(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics)
+		report.AddNotable("deleted %d attached volume(s) %v", len(deleted), deleted)
+		if len(failed) > 0 {
+			report.AddNotable("did not delete %d volume(s) %v", len(failed), failed)
+			resp.Diagnostics.AddError(
+				"Volume Delete Incomplete",
+				fmt.Sprintf("Deleted volume(s) %v of instance '%s', but volume(s) %v were not deleted and may need manual cleanup.", deleted, data.ID.ValueString(), failed),
+			)
+		}
+	case helpers.DeleteVolumesNone:
+		report.AddNotable("left attached volumes in place")
+	default:
+		helpers.DetachAllVolumes(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics)
+		if !resp.Diagnostics.HasError() {
+			report.Add("detached attached volumes")
+		}
+	}
 	if resp.Diagnostics.HasError() {
+		report.AddNotable("volume handling (%s) failed", strategy.Volumes)
 		return
 	}
 
 	helpers.DisconnectAllNetworks(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), &resp.Diagnostics)
+	report.Add("disconnected non-default networks")
+
+	if strategy.StopBeforeDelete {
+		policy := helpers.NewStopPolicy(data.StopMode, data.GracefulStopTimeout)
+		if err := helpers.EnsureInstanceStopped(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), policy, &resp.Diagnostics); err != nil {
+			report.AddNotable("stopping instance (mode: %s) failed", policy.Mode)
+			return
+		}
+		report.AddNotable("stopped instance (mode: %s)", policy.Mode)
+	}
 
 	initialInstances, err := r.client.ListInstances(data.ZoneID.ValueString())
 	if err != nil {
@@ -435,6 +557,13 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	_, err = r.client.DeleteInstance(data.ZoneID.ValueString(), data.ID.ValueString(), data.Name.ValueString())
 	if err != nil {
 		helpers.HandleAPIError(&resp.Diagnostics, "Client Error", fmt.Errorf("unable to delete instance: %w", err))
+		report.AddNotable("instance deletion request failed")
+		return
+	}
+	report.Add("requested instance deletion")
+
+	if !strategy.WaitForExpunge {
+		report.AddNotable("skipped waiting for the instance to be removed")
 		return
 	}
 
@@ -457,8 +586,10 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	err = helpers.WaitForResourceDeletion(r.client, data.ZoneID.ValueString(), data.ID.ValueString(), listFunc, checkFunc, helpers.MaxInstanceDeletionRetries, helpers.DefaultPollInterval)
 	if err != nil {
 		helpers.HandleAPIError(&resp.Diagnostics, "Client Error", fmt.Errorf("instance was not deleted successfully: %w", err))
+		report.AddNotable("waiting for the instance to be removed failed")
 		return
 	}
+	report.Add("confirmed instance was removed")
 }
 
 func (r *instanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -522,9 +653,15 @@ func (r *instanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		if isNewResource || (currentStatus != "" && helpers.IsInstanceRunning(currentStatus)) {
 			operations = append(operations, "stop")
 		}
-	} else if desiredStatus == "reboot" {
-		if !isNewResource && currentStatus != "" && helpers.IsInstanceRunning(currentStatus) {
+	}
+
+	rebootNeeded := false
+	if !isNewResource && rebootTriggerChanged(plan.RebootTrigger, state.RebootTrigger) {
+		if desiredStatus == "stopped" || (currentStatus != "" && !helpers.IsInstanceRunning(currentStatus) && desiredStatus != "running") {
+			resp.Diagnostics.AddWarning("Reboot Will Be Skipped", "reboot_trigger changed but the instance will not be running, so no reboot will be performed.")
+		} else {
 			operations = append(operations, "reboot")
+			rebootNeeded = true
 		}
 	}
 
@@ -541,7 +678,7 @@ func (r *instanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		return
 	}
 
-	if statusAlreadyMatches && !rebuildNeeded {
+	if statusAlreadyMatches && !rebuildNeeded && !rebootNeeded {
 		return
 	}
 
@@ -559,5 +696,18 @@ func (r *instanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 	}
 
 	message += fmt.Sprintf("  Operations to perform: %v", operations)
+	if slices.Contains(operations, "stop") || rebuildNeeded {
+		policy := helpers.NewStopPolicy(plan.StopMode, plan.GracefulStopTimeout)
+		message += fmt.Sprintf("\n  Stop mode: %s (graceful timeout: %s)", policy.Mode, policy.GracefulTimeout)
+	}
 	resp.Diagnostics.AddWarning("Instance Lifecycle Operations", message)
 }
+
+// rebootTriggerChanged reports whether reboot_trigger changed to a new,
+// non-empty value. Removing the trigger does not reboot the instance.
+func rebootTriggerChanged(plan, state types.Map) bool {
+	if plan.IsNull() || plan.IsUnknown() || len(plan.Elements()) == 0 {
+		return false
+	}
+	return !plan.Equal(state)
+}
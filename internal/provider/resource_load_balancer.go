@@ -4,20 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
 	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 // Ensure the implementation satisfies the resource.Resource interface.
 var _ resource.Resource = &loadBalancerResource{}
+var _ resource.ResourceWithModifyPlan = &loadBalancerResource{}
 
 func NewLoadBalancerResource(mutex *sync.Mutex) resource.Resource {
 	return &loadBalancerResource{mutex: mutex}
@@ -34,7 +40,10 @@ func (r *loadBalancerResource) Metadata(ctx context.Context, req resource.Metada
 
 func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Virak Cloud load balancer rule.",
+		MarkdownDescription: "Manages a Virak Cloud load balancer rule. " +
+			"The API cannot update rules, so changing `name` or `algorithm` creates a replacement rule before deleting the old one, which changes `id`; if the API rejects a second rule on the same public port, the old rule is deleted first. " +
+			"Backends assigned with `virakcloud_load_balancer_backend` are removed before such a swap and reassigned after it, so the rule has none of them in between. " +
+			"Set `backend_instance_ids` to manage the backend pool here instead of with `virakcloud_load_balancer_backend`; do not use both for the same rule.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -67,35 +76,48 @@ func (r *loadBalancerResource) Schema(ctx context.Context, req resource.SchemaRe
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The name of the load balancer rule.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"algorithm": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The load balancing algorithm (e.g., 'roundrobin', 'leastconn').",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"public_port": schema.Int64Attribute{
 				Required:            true,
 				MarkdownDescription: "The public port for the load balancer.",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"private_port": schema.Int64Attribute{
 				Required:            true,
 				MarkdownDescription: "The private port for backend instances.",
-				PlanModifiers:       []planmodifier.Int64{
-					// TODO: Add RequiresReplace if needed
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The status of the load balancer rule.",
 			},
+			"live_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The runtime status HAProxy reports for the rule. Null until HAProxy picks the rule up. The API reports health per rule only, not per backend.",
+			},
+			"live_updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When HAProxy last refreshed `live_status`, as a Unix timestamp.",
+			},
+			"backend_instance_ids": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "IDs of the instances that form the backend pool. Each instance's NIC on `network_id` is assigned to the rule. " +
+					"The API does not list assignments, so only assignments made through this attribute are tracked; backends added outside Terraform are not detected.",
+			},
+			"backend_attachments": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The assigned NIC (instance network ID) of each backend instance, keyed by instance ID.",
+			},
 		},
 	}
 }
@@ -132,7 +154,8 @@ func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRe
 	log.Printf("Private Port: %d", data.PrivatePort.ValueInt64())
 
 	// Create the load balancer rule
-	createResp, err := r.client.CreateLoadBalancerRule(
+	newLoadBalancerRule, err := helpers.CreateLoadBalancerRule(
+		r.client,
 		data.ZoneID.ValueString(),
 		data.NetworkID.ValueString(),
 		data.PublicIPID.ValueString(),
@@ -143,41 +166,19 @@ func (r *loadBalancerResource) Create(ctx context.Context, req resource.CreateRe
 	)
 	if err != nil {
 		log.Printf("Load balancer creation failed: %v", err)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create load balancer, got error: %s", err))
-		return
-	}
-	if !createResp.Data.Success {
-		resp.Diagnostics.AddError("Load Balancer Creation Failed", "API returned failure for load balancer creation")
-		return
-	}
-
-	log.Printf("Load balancer creation request submitted successfully")
-
-	// Find the newly created load balancer rule
-	log.Printf("Finding the newly created load balancer rule...")
-	listResp, err := r.client.ListLoadBalancerRules(data.ZoneID.ValueString(), data.NetworkID.ValueString())
-	if err != nil {
-		log.Printf("Error listing load balancer rules: %v", err)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list load balancer rules after creation, got error: %s", err))
-		return
-	}
-
-	// Find the load balancer rule by name (most recently created)
-	var newLoadBalancerRule *responses.LoadBalancerRule
-	if len(listResp.Data) > 0 {
-		// Get the last load balancer rule in the list (most recently created)
-		newLoadBalancerRule = &listResp.Data[len(listResp.Data)-1]
-		log.Printf("✓ Found load balancer rule: ID=%s, Name=%s", newLoadBalancerRule.ID, newLoadBalancerRule.Name)
-	} else {
-		log.Printf("No load balancer rules found after creation")
-		resp.Diagnostics.AddError("Load Balancer Not Found", "Could not find the created load balancer rule")
+		resp.Diagnostics.AddError("Load Balancer Creation Failed", err.Error())
 		return
 	}
+	log.Printf("✓ Found load balancer rule: ID=%s, Name=%s", newLoadBalancerRule.ID, newLoadBalancerRule.Name)
 
 	// Set the computed values
 	data.ID = types.StringValue(newLoadBalancerRule.ID)
 	data.Status = types.StringValue(newLoadBalancerRule.Status)
 
+	assigned := r.convergeBackends(ctx, &data, data.ID.ValueString(), map[string]string{}, &resp.Diagnostics)
+	data.BackendAttachments = r.backendAttachmentsValue(ctx, &data, assigned, &resp.Diagnostics)
+	r.setLiveStatus(&data)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		log.Printf("Error setting state: %v", resp.Diagnostics)
@@ -228,6 +229,8 @@ func (r *loadBalancerResource) Read(ctx context.Context, req resource.ReadReques
 	data.PublicPort = types.Int64Value(int64(foundLoadBalancerRule.PublicPort))
 	data.PrivatePort = types.Int64Value(int64(foundLoadBalancerRule.PrivatePort))
 	data.Status = types.StringValue(foundLoadBalancerRule.Status)
+	r.setLiveStatus(&data)
+	r.refreshBackendAttachments(ctx, &data, &resp.Diagnostics)
 
 	log.Printf("Updated load balancer state:")
 	log.Printf("  - ID: %s", foundLoadBalancerRule.ID)
@@ -246,6 +249,61 @@ func (r *loadBalancerResource) Read(ctx context.Context, req resource.ReadReques
 	log.Printf("=== Load Balancer Read Completed ===")
 }
 
+func (r *loadBalancerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan models.LoadBalancerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		if plan.BackendInstanceIDs.IsNull() {
+			plan.BackendAttachments = types.MapNull(types.StringType)
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+		return
+	}
+
+	var state models.LoadBalancerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	swap := loadBalancerNeedsSwap(&plan, &state)
+	if swap {
+		plan.ID = types.StringUnknown()
+		plan.Status = types.StringUnknown()
+		plan.LiveStatus = types.StringUnknown()
+		plan.LiveUpdated = types.StringUnknown()
+	} else {
+		plan.Status = state.Status
+		plan.LiveStatus = state.LiveStatus
+		plan.LiveUpdated = state.LiveUpdated
+	}
+
+	if swap || r.backendsChanged(ctx, &plan, &state, &resp.Diagnostics) {
+		plan.BackendAttachments = types.MapUnknown(types.StringType)
+	} else {
+		plan.BackendAttachments = state.BackendAttachments
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+	if swap {
+		resp.Diagnostics.AddWarning(
+			"Load Balancer Rule Will Be Swapped",
+			fmt.Sprintf("The API cannot update load balancer rules. Rule %s will be replaced by a new rule with the same ports, so its ID changes. "+
+				"The new rule is created before the old one is deleted; if the API rejects a second rule on the same public port, the old rule is deleted first and the port is not served in between. "+
+				"Backends in backend_instance_ids are assigned to the new rule before the old one is deleted. "+
+				"Backends assigned with virakcloud_load_balancer_backend reference the old ID: Terraform removes them before the swap and assigns them to the new rule only afterwards, so the rule has none of those backends during the swap.", state.ID.ValueString()),
+		)
+	}
+}
+
 func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
@@ -258,12 +316,113 @@ func (r *loadBalancerResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	log.Printf("=== Starting Load Balancer Update ===")
-	log.Printf("Current Name: %s", state.Name.ValueString())
-	log.Printf("New Name: %s", plan.Name.ValueString())
+	if !loadBalancerNeedsSwap(&plan, &state) {
+		assigned := r.convergeBackends(ctx, &plan, state.ID.ValueString(), r.backendAttachments(ctx, &state, &resp.Diagnostics), &resp.Diagnostics)
+		plan.BackendAttachments = r.backendAttachmentsValue(ctx, &plan, assigned, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	log.Printf("=== Starting Load Balancer Swap ===")
+	log.Printf("Old Rule: %s (%s, %s)", state.ID.ValueString(), state.Name.ValueString(), state.Algorithm.ValueString())
+	log.Printf("New Rule: %s, %s", plan.Name.ValueString(), plan.Algorithm.ValueString())
+
+	createRule := func() (responses.LoadBalancerRule, error) {
+		return helpers.CreateLoadBalancerRule(
+			r.client,
+			plan.ZoneID.ValueString(),
+			plan.NetworkID.ValueString(),
+			plan.PublicIPID.ValueString(),
+			plan.Name.ValueString(),
+			plan.Algorithm.ValueString(),
+			int(plan.PublicPort.ValueInt64()),
+			int(plan.PrivatePort.ValueInt64()),
+		)
+	}
+
+	// Create the replacement first so the public port keeps being served.
+	oldDeleted := false
+	newRule, err := createRule()
+	if err != nil && helpers.IsPortConflictError(err) {
+		// The API refuses a second rule on the same public port, so fall back
+		// to delete-then-create. The port is not served in between.
+		log.Printf("Replacement rule conflicts with %s on port %d, deleting the old rule first: %v", state.ID.ValueString(), state.PublicPort.ValueInt64(), err)
+		if err := helpers.DeleteLoadBalancerRule(r.client, state.ZoneID.ValueString(), state.NetworkID.ValueString(), state.ID.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Load Balancer Update Failed",
+				fmt.Sprintf("The replacement rule conflicts with rule %s on public port %d, and the old rule could not be deleted; it was left unchanged. Error: %s", state.ID.ValueString(), state.PublicPort.ValueInt64(), err),
+			)
+			return
+		}
+		oldDeleted = true
+		log.Printf("✓ Old rule deleted: %s", state.ID.ValueString())
+
+		newRule, err = createRule()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Load Balancer Update Failed",
+				fmt.Sprintf("Rule %s was deleted to free public port %d, but the replacement rule could not be created. The next apply will create it. Error: %s", state.ID.ValueString(), state.PublicPort.ValueInt64(), err),
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Load Balancer Rule Recreated",
+			fmt.Sprintf("The API does not allow two rules on public port %d, so rule %s was deleted before its replacement %s was created. The port was not served in between.", state.PublicPort.ValueInt64(), state.ID.ValueString(), newRule.ID),
+		)
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Load Balancer Update Failed",
+			fmt.Sprintf("Unable to create the replacement rule; rule %s was left unchanged. Error: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	log.Printf("✓ Replacement rule created: %s", newRule.ID)
+
+	plan.ID = types.StringValue(newRule.ID)
+	plan.Status = types.StringValue(newRule.Status)
+
+	// Fill the new pool before the old rule goes away.
+	var assignDiags diag.Diagnostics
+	assigned := r.convergeBackends(ctx, &plan, newRule.ID, map[string]string{}, &assignDiags)
+	if assignDiags.HasError() {
+		resp.Diagnostics.Append(assignDiags...)
+		if oldDeleted {
+			// There is no old rule to fall back to; keep the new one.
+			plan.BackendAttachments = r.backendAttachmentsValue(ctx, &plan, assigned, &resp.Diagnostics)
+			r.setLiveStatus(&plan)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+		if err := helpers.DeleteLoadBalancerRule(r.client, plan.ZoneID.ValueString(), plan.NetworkID.ValueString(), newRule.ID); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Replacement Load Balancer Rule Not Deleted",
+				fmt.Sprintf("Rule %s could not be deleted after its backends failed to assign and must be removed manually: %s", newRule.ID, err),
+			)
+		}
+		resp.Diagnostics.AddError("Load Balancer Update Failed", fmt.Sprintf("Unable to assign backends to the replacement rule; rule %s was left unchanged.", state.ID.ValueString()))
+		return
+	}
+	resp.Diagnostics.Append(assignDiags...)
+
+	plan.BackendAttachments = r.backendAttachmentsValue(ctx, &plan, assigned, &resp.Diagnostics)
+
+	// Unless it was already deleted to free the public port, remove the old rule.
+	if !oldDeleted {
+		if err := helpers.DeleteLoadBalancerRule(r.client, state.ZoneID.ValueString(), state.NetworkID.ValueString(), state.ID.ValueString()); err != nil {
+			// The new rule is in place; record it and surface the leftover old rule.
+			resp.Diagnostics.AddWarning(
+				"Old Load Balancer Rule Not Deleted",
+				fmt.Sprintf("Rule %s was replaced by %s but could not be deleted and must be removed manually: %s", state.ID.ValueString(), newRule.ID, err),
+			)
+		} else {
+			log.Printf("✓ Old rule deleted: %s", state.ID.ValueString())
+		}
+	}
 
-	// Load balancer rules are immutable - require replacement for changes
-	resp.Diagnostics.AddError("Load Balancer Update Not Supported", "Load balancer rules cannot be updated. Please create a new load balancer rule with the desired changes.")
+	r.setLiveStatus(&plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	log.Printf("=== Load Balancer Swap Completed ===")
 }
 
 func (r *loadBalancerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -283,17 +442,195 @@ func (r *loadBalancerResource) Delete(ctx context.Context, req resource.DeleteRe
 	log.Printf("Network ID: %s", data.NetworkID.ValueString())
 
 	// Delete the load balancer rule
-	deleteResp, err := r.client.DeleteLoadBalancerRule(data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.ID.ValueString())
-	if err != nil {
+	if err := helpers.DeleteLoadBalancerRule(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.ID.ValueString()); err != nil {
 		log.Printf("Load balancer deletion failed: %v", err)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete load balancer, got error: %s", err))
-		return
-	}
-	if !deleteResp.Data.Success {
-		resp.Diagnostics.AddError("Load Balancer Deletion Failed", "API returned failure for load balancer deletion")
+		resp.Diagnostics.AddError("Load Balancer Deletion Failed", err.Error())
 		return
 	}
 
 	log.Printf("✓ Load balancer deletion request submitted successfully")
 	log.Printf("=== Load Balancer Deletion Completed ===")
 }
+
+// setLiveStatus refreshes the HAProxy runtime status. Failures are logged
+// rather than reported, since the rule itself is readable.
+func (r *loadBalancerResource) setLiveStatus(data *models.LoadBalancerResourceModel) {
+	data.LiveStatus = types.StringNull()
+	data.LiveUpdated = types.StringNull()
+
+	live, err := helpers.GetLoadBalancerLiveStatus(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	if live == nil {
+		log.Printf("HAProxy does not report load balancer rule %s yet", data.ID.ValueString())
+		return
+	}
+	data.LiveStatus = types.StringValue(live.Status)
+	data.LiveUpdated = types.StringValue(fmt.Sprintf("%d", live.UpdatedAt))
+}
+
+// loadBalancerNeedsSwap reports whether a plan changes attributes the API can
+// only apply by creating a new rule.
+func loadBalancerNeedsSwap(plan, state *models.LoadBalancerResourceModel) bool {
+	return !plan.Name.Equal(state.Name) || !plan.Algorithm.Equal(state.Algorithm)
+}
+
+// convergeBackends assigns and deassigns NICs on ruleID until exactly the
+// instances in backend_instance_ids are backends. current maps instance IDs
+// to the NICs already assigned. New backends are assigned before old ones
+// are removed so the pool never runs empty. It returns the resulting
+// assignments, including partial progress on error.
+func (r *loadBalancerResource) convergeBackends(ctx context.Context, data *models.LoadBalancerResourceModel, ruleID string, current map[string]string, diags *diag.Diagnostics) map[string]string {
+	zoneID := data.ZoneID.ValueString()
+	networkID := data.NetworkID.ValueString()
+
+	var desired []string
+	if !data.BackendInstanceIDs.IsNull() && !data.BackendInstanceIDs.IsUnknown() {
+		diags.Append(data.BackendInstanceIDs.ElementsAs(ctx, &desired, false)...)
+		if diags.HasError() {
+			return current
+		}
+	}
+	sort.Strings(desired)
+
+	result := make(map[string]string, len(current))
+	for instanceID, nicID := range current {
+		result[instanceID] = nicID
+	}
+
+	wanted := make(map[string]struct{}, len(desired))
+	var toAssign []string
+	var nicIDs []string
+	for _, instanceID := range desired {
+		wanted[instanceID] = struct{}{}
+		if _, ok := current[instanceID]; ok {
+			continue
+		}
+		attachment, err := helpers.FindInstanceAttachment(r.client, zoneID, networkID, instanceID)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to look up the NIC of instance %s, got error: %s", instanceID, err))
+			return result
+		}
+		if attachment == nil {
+			diags.AddAttributeError(
+				path.Root("backend_instance_ids"),
+				"Backend Instance Not Attached",
+				fmt.Sprintf("Instance %s has no NIC on network %s, so it cannot be a backend of this load balancer.", instanceID, networkID),
+			)
+			return result
+		}
+		toAssign = append(toAssign, instanceID)
+		nicIDs = append(nicIDs, attachment.ID)
+	}
+
+	if len(nicIDs) > 0 {
+		log.Printf("Assigning %d backends to load balancer rule %s...", len(nicIDs), ruleID)
+		assignResp, err := r.client.AssignLoadBalancerRule(zoneID, networkID, ruleID, nicIDs)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to assign backends to load balancer, got error: %s", err))
+			return result
+		}
+		if !assignResp.Data.Success {
+			diags.AddError("Load Balancer Backend Assignment Failed", "API returned failure for load balancer backend assignment")
+			return result
+		}
+		for i, instanceID := range toAssign {
+			result[instanceID] = nicIDs[i]
+		}
+		log.Printf("✓ Backends assigned successfully")
+	}
+
+	stale := make([]string, 0)
+	for instanceID := range current {
+		if _, ok := wanted[instanceID]; !ok {
+			stale = append(stale, instanceID)
+		}
+	}
+	sort.Strings(stale)
+	for _, instanceID := range stale {
+		log.Printf("Deassigning backend %s (%s) from load balancer rule %s...", instanceID, current[instanceID], ruleID)
+		deassignResp, err := r.client.DeassignLoadBalancerRule(zoneID, networkID, ruleID, current[instanceID])
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to remove instance %s from load balancer, got error: %s", instanceID, err))
+			return result
+		}
+		if !deassignResp.Data.Success {
+			diags.AddError("Load Balancer Backend Deassignment Failed", fmt.Sprintf("API returned failure for deassigning instance %s", instanceID))
+			return result
+		}
+		delete(result, instanceID)
+	}
+
+	return result
+}
+
+// refreshBackendAttachments drops tracked backends whose NIC no longer exists,
+// for example because the instance was deleted or detached from the network.
+func (r *loadBalancerResource) refreshBackendAttachments(ctx context.Context, data *models.LoadBalancerResourceModel, diags *diag.Diagnostics) {
+	current := r.backendAttachments(ctx, data, diags)
+	if len(current) == 0 {
+		return
+	}
+
+	refreshed := make(map[string]string, len(current))
+	for instanceID, nicID := range current {
+		attachment, err := helpers.FindInstanceAttachment(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), instanceID)
+		if err != nil {
+			log.Printf("Unable to verify backend %s, keeping it: %v", instanceID, err)
+			refreshed[instanceID] = nicID
+			continue
+		}
+		if attachment == nil || attachment.ID != nicID {
+			log.Printf("Backend %s no longer has NIC %s on the network, removing from state", instanceID, nicID)
+			continue
+		}
+		refreshed[instanceID] = nicID
+	}
+
+	data.BackendAttachments = r.backendAttachmentsValue(ctx, data, refreshed, diags)
+}
+
+// backendAttachments returns the tracked instance ID to NIC assignments.
+func (r *loadBalancerResource) backendAttachments(ctx context.Context, data *models.LoadBalancerResourceModel, diags *diag.Diagnostics) map[string]string {
+	attachments := map[string]string{}
+	if data.BackendAttachments.IsNull() || data.BackendAttachments.IsUnknown() {
+		return attachments
+	}
+	diags.Append(data.BackendAttachments.ElementsAs(ctx, &attachments, false)...)
+	return attachments
+}
+
+// backendAttachmentsValue converts assignments to state. The attribute stays
+// null while backend_instance_ids is unset and nothing is assigned.
+func (r *loadBalancerResource) backendAttachmentsValue(ctx context.Context, data *models.LoadBalancerResourceModel, attachments map[string]string, diags *diag.Diagnostics) types.Map {
+	if data.BackendInstanceIDs.IsNull() && len(attachments) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	value, d := types.MapValueFrom(ctx, types.StringType, attachments)
+	diags.Append(d...)
+	return value
+}
+
+// backendsChanged reports whether the configured pool differs from the
+// tracked assignments.
+func (r *loadBalancerResource) backendsChanged(ctx context.Context, plan, state *models.LoadBalancerResourceModel, diags *diag.Diagnostics) bool {
+	if plan.BackendInstanceIDs.IsUnknown() {
+		return true
+	}
+	var desired []string
+	if !plan.BackendInstanceIDs.IsNull() {
+		diags.Append(plan.BackendInstanceIDs.ElementsAs(ctx, &desired, false)...)
+	}
+	current := r.backendAttachments(ctx, state, diags)
+	if len(desired) != len(current) {
+		return true
+	}
+	for _, instanceID := range desired {
+		if _, ok := current[instanceID]; !ok {
+			return true
+		}
+	}
+	return plan.BackendInstanceIDs.IsNull() != state.BackendAttachments.IsNull()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 // Ensure the implementation satisfies the resource.Resource interface.
@@ -190,6 +191,17 @@ func (r *loadBalancerBackendResource) Delete(ctx context.Context, req resource.D
 	log.Printf("Load Balancer ID: %s", data.LoadBalancerID.ValueString())
 	log.Printf("Instance Network ID: %s", data.InstanceNetworkID.ValueString())
 
+	// A swapped or deleted rule takes its assignments with it.
+	rule, err := helpers.FindLoadBalancerRule(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.LoadBalancerID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read load balancer rule, got error: %s", err))
+		return
+	}
+	if rule == nil {
+		log.Printf("Load balancer rule %s no longer exists, nothing to deassign", data.LoadBalancerID.ValueString())
+		return
+	}
+
 	// Remove the instance from the load balancer
 	deassignResp, err := r.client.DeassignLoadBalancerRule(
 		data.ZoneID.ValueString(),
@@ -8,11 +8,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
@@ -20,6 +24,7 @@ import (
 )
 
 var _ resource.Resource = &networkResource{}
+var _ resource.ResourceWithModifyPlan = &networkResource{}
 
 func NewNetworkResource(mutex *sync.Mutex) resource.Resource {
 	r := &networkResource{}
@@ -48,27 +53,68 @@ func (r *networkResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"name": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The name of the network.",
+				MarkdownDescription: "The name of the network. The Virak Cloud API cannot rename networks, so changing this is rejected at plan time unless the network is being replaced for another reason.",
 			},
 			"zone_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the zone where the network is located.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"network_offering_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the network offering.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The type of the network.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The IPv4 subnet of an L3 network in CIDR notation, e.g. `10.0.1.0/24`. " +
+					"An alternative to `gateway` and `netmask`: the gateway is the first usable address. " +
+					"The plan fails if the subnet overlaps another L3 network in the zone that has instances attached. " +
+					"This check is best-effort: the API only reports a network's subnet through its instance attachments, so overlaps with empty networks, including networks created in the same apply, are not detected. " +
+					"Switching between `cidr` and an equivalent `gateway` and `netmask` does not replace the network.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("gateway"), path.MatchRoot("netmask")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(networkCIDRChanged,
+						"Changing the subnet forces replacement.",
+						"Changing the subnet forces replacement."),
+				},
 			},
 			"gateway": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The gateway IP address of the network.",
+				Computed:            true,
+				MarkdownDescription: "The gateway IP address of the network. Derived from `cidr` when that is set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"netmask": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The netmask of the network.",
+				Computed:            true,
+				MarkdownDescription: "The netmask of the network. Derived from `cidr` when that is set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"force_detach_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Disconnect every attached instance before deleting the network. When false (the default), destroying a network that still has instances attached fails and lists them.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -151,9 +197,26 @@ func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest
 	var createResponse interface{}
 	var createErr error
 
+	if !data.CIDR.IsNull() {
+		prefix, err := helpers.ParseNetworkCIDR(data.CIDR.ValueString())
+		if err != nil {
+			helpers.HandleValidationError(&resp.Diagnostics, "Validation Error", err.Error())
+			return
+		}
+		gateway, netmask := helpers.CIDRGatewayNetmask(prefix)
+		data.Gateway = types.StringValue(gateway)
+		data.Netmask = types.StringValue(netmask)
+	}
+	if data.Gateway.IsUnknown() {
+		data.Gateway = types.StringNull()
+	}
+	if data.Netmask.IsUnknown() {
+		data.Netmask = types.StringNull()
+	}
+
 	if data.Type.ValueString() == "Isolated" || data.Type.ValueString() == "L3" {
 		if data.Gateway.IsNull() || data.Netmask.IsNull() {
-			helpers.HandleValidationError(&resp.Diagnostics, "Validation Error", "gateway and netmask are required for Isolated networks")
+			helpers.HandleValidationError(&resp.Diagnostics, "Validation Error", "cidr, or gateway and netmask, are required for Isolated networks")
 			return
 		}
 		log.Printf("Creating L3/Isolated network with gateway: %s, netmask: %s",
@@ -345,10 +408,15 @@ func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, re
 		ZoneID:            data.ZoneID,
 		NetworkOfferingID: types.StringValue(readResp.Data.NetworkOffering.ID),
 		Type:              data.Type,
+		CIDR:              data.CIDR,
 		Gateway:           data.Gateway,
 		Netmask:           data.Netmask,
 		Status:            types.StringValue(readResp.Data.Status),
 		Instances:         instancesList,
+		ForceDetach:       data.ForceDetach,
+	}
+	if updatedData.ForceDetach.IsNull() {
+		updatedData.ForceDetach = types.BoolValue(false)
 	}
 
 	log.Printf("Updated network state:")
@@ -381,75 +449,9 @@ func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest
 	log.Printf("=== Network Update Requested ===")
 	log.Printf("Network ID: %s", plan.ID.ValueString())
 
-	configurableAttributesChanged := false
-	changedAttributes := make([]string, 0)
-
-	if !plan.Name.Equal(state.Name) {
-		log.Printf("Name changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.Name.ValueString(), plan.Name.ValueString(),
-			state.Name.IsNull(), state.Name.IsUnknown(),
-			plan.Name.IsNull(), plan.Name.IsUnknown())
-		configurableAttributesChanged = true
-		changedAttributes = append(changedAttributes, "name")
-	}
-	if !plan.ZoneID.Equal(state.ZoneID) {
-		log.Printf("Zone ID changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.ZoneID.ValueString(), plan.ZoneID.ValueString(),
-			state.ZoneID.IsNull(), state.ZoneID.IsUnknown(),
-			plan.ZoneID.IsNull(), plan.ZoneID.IsUnknown())
-		configurableAttributesChanged = true
-		changedAttributes = append(changedAttributes, "zone_id")
-	}
-	if !plan.NetworkOfferingID.Equal(state.NetworkOfferingID) {
-		log.Printf("Network Offering ID changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.NetworkOfferingID.ValueString(), plan.NetworkOfferingID.ValueString(),
-			state.NetworkOfferingID.IsNull(), state.NetworkOfferingID.IsUnknown(),
-			plan.NetworkOfferingID.IsNull(), plan.NetworkOfferingID.IsUnknown())
-		configurableAttributesChanged = true
-		changedAttributes = append(changedAttributes, "network_offering_id")
-	}
-	if !plan.Type.Equal(state.Type) {
-		log.Printf("Type changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.Type.ValueString(), plan.Type.ValueString(),
-			state.Type.IsNull(), state.Type.IsUnknown(),
-			plan.Type.IsNull(), plan.Type.IsUnknown())
-		configurableAttributesChanged = true
-		changedAttributes = append(changedAttributes, "type")
-	}
-	if !plan.Gateway.Equal(state.Gateway) {
-		log.Printf("Gateway changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.Gateway.ValueString(), plan.Gateway.ValueString(),
-			state.Gateway.IsNull(), state.Gateway.IsUnknown(),
-			plan.Gateway.IsNull(), plan.Gateway.IsUnknown())
-		if !plan.Gateway.IsUnknown() && !state.Gateway.IsUnknown() {
-			if plan.Gateway.ValueString() != state.Gateway.ValueString() {
-				configurableAttributesChanged = true
-				changedAttributes = append(changedAttributes, "gateway")
-			}
-		}
-	}
-	if !plan.Netmask.Equal(state.Netmask) {
-		log.Printf("Netmask changed: '%s' -> '%s' (state: null=%v, unknown=%v | plan: null=%v, unknown=%v)",
-			state.Netmask.ValueString(), plan.Netmask.ValueString(),
-			state.Netmask.IsNull(), state.Netmask.IsUnknown(),
-			plan.Netmask.IsNull(), plan.Netmask.IsUnknown())
-		if !plan.Netmask.IsUnknown() && !state.Netmask.IsUnknown() {
-			if plan.Netmask.ValueString() != state.Netmask.ValueString() {
-				configurableAttributesChanged = true
-				changedAttributes = append(changedAttributes, "netmask")
-			}
-		}
-	}
-
-	if configurableAttributesChanged {
-		log.Printf("=== Network Update Rejected (Configurable Attributes Changed) ===")
-		log.Printf("Changed attributes: %v", changedAttributes)
-		resp.Diagnostics.AddError("Update Not Supported", fmt.Sprintf("Network resources cannot be updated after creation. Changed attributes: %v. Please destroy and recreate the network if changes are needed.", changedAttributes))
-		return
-	}
-
-	log.Printf("Only computed attributes changed, refreshing state from API...")
-
+	// Every other configurable attribute requires replacement or only respells
+	// the subnet, and ModifyPlan rejects renames, so an update only refreshes
+	// the computed attributes.
 	readResp, err := r.client.ShowNetwork(plan.ZoneID.ValueString(), plan.ID.ValueString())
 	if err != nil {
 		log.Printf("Error reading network during update: %v", err)
@@ -512,10 +514,12 @@ func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest
 		ZoneID:            plan.ZoneID,
 		NetworkOfferingID: plan.NetworkOfferingID,
 		Type:              plan.Type,
+		CIDR:              plan.CIDR,
 		Gateway:           plan.Gateway,
 		Netmask:           plan.Netmask,
 		Status:            types.StringValue(readResp.Data.Status),
 		Instances:         instancesList,
+		ForceDetach:       plan.ForceDetach,
 	}
 
 	log.Printf("Network state refreshed successfully")
@@ -556,6 +560,15 @@ func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	log.Printf("Found %d instance connections to this network", len(instances))
 
+	if len(instances) > 0 && !data.ForceDetach.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Network Has Attached Instances",
+			fmt.Sprintf("Network %s (%s) still has instances attached:\n  - %s\n\nDetach them first, or set force_detach_on_destroy = true to disconnect them automatically.",
+				data.Name.ValueString(), data.ID.ValueString(), strings.Join(helpers.DescribeNetworkAttachments(instances), "\n  - ")),
+		)
+		return
+	}
+
 	disconnectedCount := 0
 	for _, networkInstance := range instances {
 		if networkInstance.Network.ID != data.ID.ValueString() {
@@ -642,6 +655,198 @@ func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ModifyPlan derives gateway and netmask from cidr and checks the subnet for
+// overlaps when a network is created, and surfaces attached instances when a
+// network is destroyed or replaced.
+func (r *networkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		if !req.State.Raw.IsNull() {
+			r.warnAttachedInstances(ctx, req, resp)
+		}
+		return
+	}
+
+	var plan models.NetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.CIDR.IsNull() && !plan.CIDR.IsUnknown() {
+		prefix, err := helpers.ParseNetworkCIDR(plan.CIDR.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid CIDR", err.Error())
+			return
+		}
+		gateway, netmask := helpers.CIDRGatewayNetmask(prefix)
+		plan.Gateway = types.StringValue(gateway)
+		plan.Netmask = types.StringValue(netmask)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+
+	// Only new networks, including replacements, need an overlap check. The
+	// check is best-effort, see ListZoneNetworkPrefixes.
+	var currentID string
+	if !req.State.Raw.IsNull() {
+		var state models.NetworkResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !networkReplaced(plan, state) && !plan.Name.IsUnknown() && !plan.Name.Equal(state.Name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Network Rename Not Supported",
+				fmt.Sprintf("The Virak Cloud API cannot rename networks. Keep the name '%s', or declare a new network with the new name and remove this one.", state.Name.ValueString()),
+			)
+			return
+		}
+		// A replacement deletes the current network just like a destroy.
+		if networkReplaced(plan, state) {
+			r.warnAttachedInstances(ctx, req, resp)
+		}
+		if plan.Gateway.Equal(state.Gateway) && plan.Netmask.Equal(state.Netmask) {
+			return
+		}
+		currentID = state.ID.ValueString()
+	}
+
+	if plan.ZoneID.IsUnknown() || plan.Gateway.IsUnknown() || plan.Netmask.IsUnknown() || plan.Gateway.IsNull() || plan.Netmask.IsNull() {
+		return
+	}
+	prefix, ok := helpers.PrefixFromGatewayNetmask(plan.Gateway.ValueString(), plan.Netmask.ValueString())
+	if !ok {
+		return
+	}
+
+	existing, err := helpers.ListZoneNetworkPrefixes(r.client, plan.ZoneID.ValueString())
+	if err != nil {
+		log.Printf("Warning: Unable to list networks in zone %s for overlap check: %v", plan.ZoneID.ValueString(), err)
+		return
+	}
+	for _, network := range existing {
+		if network.ID == currentID || !network.Prefix.Overlaps(prefix) {
+			continue
+		}
+		attrPath := path.Root("gateway")
+		if !plan.CIDR.IsNull() {
+			attrPath = path.Root("cidr")
+		}
+		resp.Diagnostics.AddAttributeError(
+			attrPath,
+			"Overlapping Network CIDR",
+			fmt.Sprintf("Subnet %s overlaps network %s (%s) which uses %s in zone %s.", prefix, network.Name, network.ID, network.Prefix, plan.ZoneID.ValueString()),
+		)
+	}
+}
+
+// networkReplaced reports whether the plan changes an attribute that forces
+// the network to be replaced. Attribute-level RequiresReplace modifiers are
+// not visible in ModifyPlan, so the attributes are compared directly, with
+// cidr compared through the gateway and netmask derived from it.
+func networkReplaced(plan, state models.NetworkResourceModel) bool {
+	if plan.CIDR.IsUnknown() {
+		return true
+	}
+	for _, pair := range [][2]types.String{
+		{plan.ZoneID, state.ZoneID},
+		{plan.NetworkOfferingID, state.NetworkOfferingID},
+		{plan.Type, state.Type},
+		{plan.Gateway, state.Gateway},
+		{plan.Netmask, state.Netmask},
+	} {
+		if !pair[0].Equal(pair[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkCIDRChanged requires replacement only when cidr describes a subnet
+// other than the current gateway and netmask. Removing cidr leaves the
+// decision to gateway and netmask.
+func networkCIDRChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+	if req.PlanValue.IsNull() {
+		return
+	}
+	prefix, err := helpers.ParseNetworkCIDR(req.PlanValue.ValueString())
+	if err != nil {
+		// ModifyPlan reports the invalid value.
+		return
+	}
+
+	var gateway, netmask types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("gateway"), &gateway)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("netmask"), &netmask)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	wantGateway, wantNetmask := helpers.CIDRGatewayNetmask(prefix)
+	resp.RequiresReplace = gateway.ValueString() != wantGateway || netmask.ValueString() != wantNetmask
+}
+
+// warnAttachedInstances surfaces the attachments that a planned destroy or
+// replacement would disconnect, or that would make it fail. Delete never
+// detaches default NICs, and detaches nothing when force_detach_on_destroy is
+// false. The provider cannot see other resources in the plan, so attachments
+// managed in the same configuration are listed too and the warning says so.
+func (r *networkResource) warnAttachedInstances(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state models.NetworkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attachments, err := helpers.GetNetworkInstances(r.client, state.ZoneID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		log.Printf("Warning: Unable to list instances attached to network %s during plan: %v", state.ID.ValueString(), err)
+		return
+	}
+	if len(attachments) == 0 {
+		return
+	}
+
+	if !state.ForceDetach.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Network Has Attached Instances",
+			fmt.Sprintf("Network %s (%s) currently has instances attached:\n  - %s\n\nInstances and attachments managed in this configuration are removed by Terraform before the network is deleted. Any others will make the deletion fail because force_detach_on_destroy is false.",
+				state.Name.ValueString(), state.ID.ValueString(), strings.Join(helpers.DescribeNetworkAttachments(attachments), "\n  - ")),
+		)
+		return
+	}
+
+	var secondary, defaults []responses.InstanceNetwork
+	for _, ni := range attachments {
+		if ni.IsDefault {
+			defaults = append(defaults, ni)
+		} else {
+			secondary = append(secondary, ni)
+		}
+	}
+	if len(secondary) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Network Instances Will Be Disconnected",
+			fmt.Sprintf("Deleting network %s (%s) will disconnect these instances unless this configuration detaches them first:\n  - %s",
+				state.Name.ValueString(), state.ID.ValueString(), strings.Join(helpers.DescribeNetworkAttachments(secondary), "\n  - ")),
+		)
+	}
+	if len(defaults) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Network Is a Default Network",
+			fmt.Sprintf("Network %s (%s) is the default network of these instances, which force_detach_on_destroy cannot disconnect:\n  - %s\n\nInstances managed in this configuration are destroyed by Terraform before the network is deleted. Any others will make the deletion fail.",
+				state.Name.ValueString(), state.ID.ValueString(), strings.Join(helpers.DescribeNetworkAttachments(defaults), "\n  - ")),
+		)
+	}
+}
+
 func (r *networkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data models.NetworkResourceModel
 
@@ -656,6 +861,15 @@ func (r *networkResource) ValidateConfig(ctx context.Context, req resource.Valid
 	log.Printf("Network Offering ID: %s", data.NetworkOfferingID.ValueString())
 	log.Printf("Network Type: %s", data.Type.ValueString())
 
+	if !data.CIDR.IsNull() && !data.CIDR.IsUnknown() {
+		if _, err := helpers.ParseNetworkCIDR(data.CIDR.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid CIDR", err.Error())
+		}
+		if data.Type.ValueString() == "L2" {
+			resp.Diagnostics.AddAttributeError(path.Root("cidr"), "Invalid Configuration", "cidr can only be set on Isolated (L3) networks.")
+		}
+	}
+
 	// Validate network offering type
 	if !data.NetworkOfferingID.IsNull() && !data.NetworkOfferingID.IsUnknown() && !data.Type.IsNull() && !data.Type.IsUnknown() {
 		if err := r.validateNetworkOfferingType(data.ZoneID.ValueString(), data.NetworkOfferingID.ValueString(), data.Type.ValueString(), resp); err != nil {
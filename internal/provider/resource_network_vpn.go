@@ -5,18 +5,25 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 // Ensure the implementation satisfies the resource.Resource interface.
 var _ resource.Resource = &networkVPNResource{}
+var _ resource.ResourceWithModifyPlan = &networkVPNResource{}
+var _ resource.ResourceWithValidateConfig = &networkVPNResource{}
 
 func NewNetworkVPNResource(mutex *sync.Mutex) resource.Resource {
 	return &networkVPNResource{mutex: mutex}
@@ -64,6 +71,8 @@ func (r *networkVPNResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 				MarkdownDescription: "The pre-shared key for VPN authentication.",
 				Sensitive:           true,
+				DeprecationMessage: "The API generates the pre-shared key itself and ignores this value; changing it only rotates the credentials. " +
+					"Use rotation_trigger to rotate credentials and the virakcloud_network_vpn_credentials ephemeral resource to read them.",
 			},
 			"ip_address": schema.StringAttribute{
 				Computed:            true,
@@ -82,6 +91,52 @@ func (r *networkVPNResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:            true,
 				MarkdownDescription: "The status of the VPN configuration.",
 			},
+			"network_cidr": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The subnet routed through the VPN by the generated client configurations, e.g. `10.0.1.0/24`. " +
+					"Defaults to the network's subnet when it can be determined from its attached instances.",
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary value; changing it rotates the VPN credentials on the next apply, e.g. `rotation_trigger = \"2026-Q4\"`.",
+			},
+			"rotate_after": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Rotate the VPN credentials once they are older than this duration, e.g. `720h` or `30d`. " +
+					"The age is checked on every plan, so rotation happens on the first apply after the limit is reached.",
+			},
+			"credentials_rotated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp of when the current credentials were issued by this resource.",
+			},
+			"client_config": schema.SingleNestedAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Ready-to-use L2TP/IPsec client configurations built from the VPN credentials. Null while the VPN is disabled.",
+				Attributes: map[string]schema.Attribute{
+					"strongswan_ipsec_conf": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "A strongSwan `ipsec.conf` connection.",
+					},
+					"strongswan_ipsec_secrets": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The matching `ipsec.secrets` entry.",
+					},
+					"xl2tpd_conf": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "An `xl2tpd.conf` LAC section for the L2TP tunnel.",
+					},
+					"ppp_options": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The PPP options file referenced by `xl2tpd_conf`, including the VPN username and password.",
+					},
+					"networkmanager_keyfile": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "A NetworkManager keyfile (`.nmconnection`) for the L2TP plugin.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -135,21 +190,11 @@ func (r *networkVPNResource) Create(ctx context.Context, req resource.CreateRequ
 		log.Printf("VPN will be created in disabled state")
 	}
 
-	// If preshared key is provided, update credentials
-	if !data.PresharedKey.IsNull() && data.PresharedKey.ValueString() != "" {
-		log.Printf("Updating VPN preshared key...")
-		// Note: The UpdateNetworkVpnCredentials method doesn't take a preshared key parameter
-		// This might need to be handled differently or the API might expect it in a different way
-		updateResp, err := r.client.UpdateNetworkVpnCredentials(data.ZoneID.ValueString(), data.NetworkID.ValueString())
-		if err != nil {
-			log.Printf("VPN credentials update failed: %v", err)
-			resp.Diagnostics.AddWarning("VPN Credentials Update Warning", fmt.Sprintf("Failed to update VPN credentials: %s", err))
-			// Continue with creation even if credentials update fails
-		} else if !updateResp.Data.Success {
-			resp.Diagnostics.AddWarning("VPN Credentials Update Warning", "API returned failure for VPN credentials update")
-		} else {
-			log.Printf("✓ VPN credentials updated successfully")
-		}
+	// Enabling the VPN issues a fresh set of credentials.
+	if data.Enabled.ValueBool() {
+		data.CredentialsRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	} else {
+		data.CredentialsRotatedAt = types.StringNull()
 	}
 
 	// Get VPN details to populate computed fields
@@ -170,6 +215,7 @@ func (r *networkVPNResource) Create(ctx context.Context, req resource.CreateRequ
 		data.Status = types.StringValue(detailsResp.Data.Status)
 		log.Printf("✓ VPN details retrieved: IP=%s, Status=%s", detailsResp.Data.IPAddress, detailsResp.Data.Status)
 	}
+	r.setClientConfig(&data, detailsResp, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -214,6 +260,7 @@ func (r *networkVPNResource) Read(ctx context.Context, req resource.ReadRequest,
 	} else {
 		data.Enabled = types.BoolValue(false)
 	}
+	r.setClientConfig(&data, detailsResp, &resp.Diagnostics)
 
 	log.Printf("Updated VPN state:")
 	log.Printf("  - IP Address: %s", detailsResp.Data.IPAddress)
@@ -277,20 +324,26 @@ func (r *networkVPNResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 	}
 
-	// Handle preshared key changes
-	if !plan.PresharedKey.Equal(state.PresharedKey) {
-		log.Printf("Updating VPN preshared key...")
+	if !plan.Enabled.ValueBool() {
+		plan.CredentialsRotatedAt = state.CredentialsRotatedAt
+	} else if !state.Enabled.ValueBool() {
+		plan.CredentialsRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	} else if reason := vpnRotationReason(plan, state, time.Now()); reason != "" {
+		log.Printf("Rotating VPN credentials: %s", reason)
 		updateResp, err := r.client.UpdateNetworkVpnCredentials(plan.ZoneID.ValueString(), plan.NetworkID.ValueString())
 		if err != nil {
 			log.Printf("VPN credentials update failed: %v", err)
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update VPN credentials, got error: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate VPN credentials, got error: %s", err))
 			return
 		}
 		if !updateResp.Data.Success {
-			resp.Diagnostics.AddError("VPN Credentials Update Failed", "API returned failure for VPN credentials update")
+			resp.Diagnostics.AddError("VPN Credentials Rotation Failed", "API returned failure for VPN credentials update")
 			return
 		}
-		log.Printf("✓ VPN credentials updated successfully")
+		plan.CredentialsRotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+		log.Printf("✓ VPN credentials rotated successfully")
+	} else {
+		plan.CredentialsRotatedAt = state.CredentialsRotatedAt
 	}
 
 	// Get updated VPN details
@@ -304,6 +357,7 @@ func (r *networkVPNResource) Update(ctx context.Context, req resource.UpdateRequ
 		plan.Password = types.StringValue(detailsResp.Data.Password)
 		plan.Status = types.StringValue(detailsResp.Data.Status)
 	}
+	r.setClientConfig(&plan, detailsResp, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -348,3 +402,113 @@ func (r *networkVPNResource) Delete(ctx context.Context, req resource.DeleteRequ
 	log.Printf("✓ Network VPN deletion completed successfully")
 	log.Printf("=== Network VPN Deletion Completed ===")
 }
+
+func (r *networkVPNResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.NetworkVPNResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.RotateAfter.IsNull() && !data.RotateAfter.IsUnknown() {
+		if _, err := helpers.ParseRotationPeriod(data.RotateAfter.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rotate_after"), "Invalid Rotation Period", err.Error())
+		}
+	}
+}
+
+// ModifyPlan marks the credentials as changing when a rotation is due, so that
+// an expired rotate_after alone produces a plan. Otherwise the rotation time
+// is carried over from state.
+func (r *networkVPNResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan models.NetworkVPNResourceModel
+	var state models.NetworkVPNResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotating := plan.Enabled.ValueBool() && !state.Enabled.ValueBool()
+	if plan.Enabled.ValueBool() && state.Enabled.ValueBool() {
+		if reason := vpnRotationReason(plan, state, time.Now()); reason != "" {
+			resp.Diagnostics.AddWarning("VPN Credentials Will Be Rotated", fmt.Sprintf("The VPN credentials for network %s will be rotated: %s.", plan.NetworkID.ValueString(), reason))
+			rotating = true
+		}
+	}
+
+	if rotating {
+		plan.Password = types.StringUnknown()
+		plan.Username = types.StringUnknown()
+		plan.ClientConfig = types.ObjectUnknown(helpers.GetVPNClientConfigObjectType().AttrTypes)
+		plan.CredentialsRotatedAt = types.StringUnknown()
+	} else {
+		plan.CredentialsRotatedAt = state.CredentialsRotatedAt
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// vpnRotationReason returns why the credentials of an enabled VPN should be
+// rotated, or "" if they should not.
+func vpnRotationReason(plan, state models.NetworkVPNResourceModel, now time.Time) string {
+	if !plan.RotationTrigger.IsUnknown() && !plan.RotationTrigger.Equal(state.RotationTrigger) {
+		return "rotation_trigger changed"
+	}
+	if !plan.PresharedKey.IsUnknown() && !plan.PresharedKey.Equal(state.PresharedKey) {
+		return "preshared_key changed"
+	}
+	if plan.RotateAfter.IsUnknown() || plan.RotateAfter.IsNull() {
+		return ""
+	}
+	if state.CredentialsRotatedAt.IsNull() {
+		return "the age of the current credentials is unknown"
+	}
+	if helpers.VPNRotationDue(state.CredentialsRotatedAt.ValueString(), plan.RotateAfter.ValueString(), now) {
+		return fmt.Sprintf("credentials issued at %s are older than %s", state.CredentialsRotatedAt.ValueString(), plan.RotateAfter.ValueString())
+	}
+	return ""
+}
+
+// setClientConfig renders client_config from the VPN details. details is nil
+// when they could not be retrieved.
+func (r *networkVPNResource) setClientConfig(data *models.NetworkVPNResourceModel, details *responses.NetworkVpnDetailResponse, diags *diag.Diagnostics) {
+	if data.NetworkCIDR.IsUnknown() || data.NetworkCIDR.IsNull() {
+		data.NetworkCIDR = types.StringNull()
+		if prefix, ok := helpers.FindNetworkPrefix(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString()); ok {
+			data.NetworkCIDR = types.StringValue(prefix.String())
+		}
+	}
+
+	if details == nil || !data.Enabled.ValueBool() || details.Data.IPAddress == "" {
+		data.ClientConfig = types.ObjectNull(helpers.GetVPNClientConfigObjectType().AttrTypes)
+		return
+	}
+
+	// The configured preshared_key is not what the API serves, so a config
+	// built from it would not connect.
+	if details.Data.PresharedKey == "" {
+		diags.AddWarning("VPN Client Configuration Unavailable", fmt.Sprintf("The API returned no preshared key for the VPN of network %s, so client_config cannot be rendered.", data.NetworkID.ValueString()))
+		data.ClientConfig = types.ObjectNull(helpers.GetVPNClientConfigObjectType().AttrTypes)
+		return
+	}
+
+	config, err := helpers.RenderVPNClientConfig(helpers.VPNCredentials{
+		Name:         "virak-" + data.NetworkID.ValueString(),
+		Server:       details.Data.IPAddress,
+		Username:     details.Data.Username,
+		Password:     details.Data.Password,
+		PresharedKey: details.Data.PresharedKey,
+		Subnet:       data.NetworkCIDR.ValueString(),
+	})
+	if err != nil {
+		diags.AddWarning("VPN Client Configuration Unavailable", err.Error())
+		data.ClientConfig = types.ObjectNull(helpers.GetVPNClientConfigObjectType().AttrTypes)
+		return
+	}
+	data.ClientConfig = helpers.CreateVPNClientConfigObject(config, diags)
+}
@@ -3,19 +3,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 	"sync"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/cli/pkg/http/responses"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 var _ resource.Resource = &portForwardingRuleResource{}
+var _ resource.ResourceWithModifyPlan = &portForwardingRuleResource{}
+var _ resource.ResourceWithValidateConfig = &portForwardingRuleResource{}
 
 func NewPortForwardingRuleResource(mutex *sync.Mutex) resource.Resource {
 	return &portForwardingRuleResource{mutex: mutex}
@@ -31,12 +41,18 @@ func (r *portForwardingRuleResource) Metadata(ctx context.Context, req resource.
 }
 
 func (r *portForwardingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	portValidators := []validator.Int64{
+		int64validator.Between(helpers.MinPort, helpers.MaxPort),
+	}
+
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Virak Cloud port forwarding rule.",
+		MarkdownDescription: "Manages a Virak Cloud port forwarding rule. " +
+			"A port range or the `both` protocol is created as one API forward per protocol and port, listed in `rule_ids`. " +
+			"Changing the ports, protocol, `instance_id` or `private_ip` deletes and recreates the forwards in place.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Port forwarding rule ID",
+				MarkdownDescription: "Port forwarding rule ID (the ID of the first forward in `rule_ids`).",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -64,35 +80,49 @@ func (r *portForwardingRuleResource) Schema(ctx context.Context, req resource.Sc
 			},
 			"protocol": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The protocol (TCP or UDP).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				MarkdownDescription: "The protocol: `TCP`, `UDP` or `both`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(helpers.PortForwardProtocolTCP, helpers.PortForwardProtocolUDP, helpers.PortForwardProtocolBoth),
 				},
 			},
 			"public_port": schema.Int64Attribute{
 				Required:            true,
-				MarkdownDescription: "The public port number.",
-				PlanModifiers:       []planmodifier.Int64{
-					// RequiresReplace if needed
-				},
+				MarkdownDescription: "The public port number, or the first port of the public range.",
+				Validators:          portValidators,
+			},
+			"public_port_end": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The last port of the public range. Defaults to `public_port`. A range spans at most 256 ports.",
+				Validators:          portValidators,
 			},
 			"private_port": schema.Int64Attribute{
 				Required:            true,
-				MarkdownDescription: "The private port number.",
-				PlanModifiers:       []planmodifier.Int64{
-					// RequiresReplace if needed
-				},
+				MarkdownDescription: "The private port number, or the first port of the private range.",
+				Validators:          portValidators,
+			},
+			"private_port_end": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The last port of the private range. Must span as many ports as the public range; defaults to that length.",
+				Validators:          portValidators,
 			},
 			"instance_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the instance.",
+			},
+			"private_ip": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The private IP address traffic is forwarded to. Defaults to the instance's address on the network.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"private_ip": schema.StringAttribute{
+			"rule_ids": schema.ListAttribute{
 				Computed:            true,
-				MarkdownDescription: "The private IP address of the instance.",
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the API forwards backing this rule, ordered by protocol and public port.",
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -122,78 +152,173 @@ func (r *portForwardingRuleResource) Configure(ctx context.Context, req resource
 	r.client = client
 }
 
-func (r *portForwardingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
-
+func (r *portForwardingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data models.PortForwardingRuleResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// First get port forward list
-	_, err := r.client.ListPortForwards(
-		data.ZoneID.ValueString(),
-		data.NetworkID.ValueString(),
-	)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to List Port Forwards",
-			fmt.Sprintf("Failed to list port forwards before creation. Error: %s", err),
+	if data.PublicPort.IsUnknown() || data.PublicPortEnd.IsUnknown() || data.PrivatePort.IsUnknown() || data.PrivatePortEnd.IsUnknown() {
+		return
+	}
+
+	publicStart := data.PublicPort.ValueInt64()
+	publicEnd := publicStart
+	if !data.PublicPortEnd.IsNull() {
+		publicEnd = data.PublicPortEnd.ValueInt64()
+	}
+	if publicEnd < publicStart {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_port_end"),
+			"Invalid Port Range",
+			fmt.Sprintf("public_port_end (%d) must not be lower than public_port (%d).", publicEnd, publicStart),
+		)
+		return
+	}
+	if size := publicEnd - publicStart + 1; size > helpers.MaxPortForwardRangeSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_port_end"),
+			"Port Range Too Large",
+			fmt.Sprintf("The public range %d-%d spans %d ports, but a port forwarding rule can span at most %d because each port is created as a separate rule. Split the range across several resources.", publicStart, publicEnd, size, helpers.MaxPortForwardRangeSize),
+		)
+		return
+	}
+
+	privateStart := data.PrivatePort.ValueInt64()
+	expectedPrivateEnd := privateStart + (publicEnd - publicStart)
+	if !data.PrivatePortEnd.IsNull() && data.PrivatePortEnd.ValueInt64() != expectedPrivateEnd {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_port_end"),
+			"Invalid Port Range",
+			fmt.Sprintf("The private range must span as many ports as the public range (%d-%d), so private_port_end must be %d.", publicStart, publicEnd, expectedPrivateEnd),
 		)
 		return
 	}
+	if expectedPrivateEnd > helpers.MaxPort {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_port"),
+			"Invalid Port Range",
+			fmt.Sprintf("A private range starting at %d cannot span %d ports.", privateStart, publicEnd-publicStart+1),
+		)
+	}
+}
 
-	body := map[string]interface{}{
-		"network_id":   data.NetworkID.ValueString(),
-		"protocol":     data.Protocol.ValueString(),
-		"public_port":  int(data.PublicPort.ValueInt64()),
-		"private_port": int(data.PrivatePort.ValueInt64()),
-		"private_ip":   data.PrivateIP.ValueString(),
+func (r *portForwardingRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
 	}
 
-	_, err = r.client.CreatePortForward(
-		data.ZoneID.ValueString(),
-		body,
-	)
+	var plan, config models.PortForwardingRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PublicPort.IsUnknown() && config.PublicPortEnd.IsNull() {
+		plan.PublicPortEnd = plan.PublicPort
+	}
+	if !plan.PublicPort.IsUnknown() && !plan.PublicPortEnd.IsUnknown() && !plan.PrivatePort.IsUnknown() && config.PrivatePortEnd.IsNull() {
+		plan.PrivatePortEnd = types.Int64Value(plan.PrivatePort.ValueInt64() + plan.PublicPortEnd.ValueInt64() - plan.PublicPort.ValueInt64())
+	}
+
+	var state *models.PortForwardingRuleResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &models.PortForwardingRuleResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if state == nil {
+		plan.RuleIDs = types.ListUnknown(types.StringType)
+		plan.Status = types.StringUnknown()
+		plan.CreatedAt = types.StringUnknown()
+	} else {
+		if config.PrivateIP.IsNull() && !plan.InstanceID.Equal(state.InstanceID) {
+			plan.PrivateIP = types.StringUnknown()
+		}
+
+		stateRuleIDs := r.stateRuleIDs(ctx, state)
+		mappingChanged := portForwardMappingChanged(&plan, state)
+		missing := !plan.Protocol.IsUnknown() && !plan.PublicPort.IsUnknown() && !plan.PublicPortEnd.IsUnknown() &&
+			len(stateRuleIDs) != len(portForwardSpecs(&plan))
+		if missing && !mappingChanged {
+			resp.Diagnostics.AddWarning(
+				"Port Forwards Missing",
+				fmt.Sprintf("Only %d of the %d forwards backing this rule still exist. They will be recreated.", len(stateRuleIDs), len(portForwardSpecs(&plan))),
+			)
+		}
+
+		if mappingChanged || missing {
+			plan.RuleIDs = types.ListUnknown(types.StringType)
+			plan.Status = types.StringUnknown()
+			plan.CreatedAt = types.StringUnknown()
+		} else {
+			plan.RuleIDs = state.RuleIDs
+			plan.Status = state.Status
+			plan.CreatedAt = state.CreatedAt
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Collisions only matter when the set of public ports changes.
+	if state != nil && plan.Protocol.Equal(state.Protocol) && plan.PublicPort.Equal(state.PublicPort) && plan.PublicPortEnd.Equal(state.PublicPortEnd) {
+		return
+	}
+	if r.client == nil || plan.ZoneID.IsUnknown() || plan.NetworkID.IsUnknown() || plan.Protocol.IsUnknown() || plan.PublicPort.IsUnknown() || plan.PublicPortEnd.IsUnknown() {
+		return
+	}
+
+	listResp, err := r.client.ListPortForwards(plan.ZoneID.ValueString(), plan.NetworkID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Port Forwarding Rule Creation Failed",
-			fmt.Sprintf("Failed to create port forwarding rule. Error: %s", err),
-		)
+		log.Printf("Unable to list port forwards for collision check: %v", err)
 		return
 	}
 
-	// Poll to ensure creation happened, with 5 second backoff for up to 600 seconds
-	for i := 0; i < 120; i++ {
-		listResp, err := r.client.ListPortForwards(
-			data.ZoneID.ValueString(),
-			data.NetworkID.ValueString(),
-		)
-		if err == nil {
-			for _, rule := range listResp.Data {
-				if rule.ID == data.ID.ValueString() {
-					data.Status = types.StringValue(rule.Status)
-					data.CreatedAt = types.StringValue(fmt.Sprintf("%d", rule.CreatedAt))
-					if rule.PrivateIP != "" {
-						data.PrivateIP = types.StringValue(rule.PrivateIP)
-					}
-					break
-				}
-			}
-			if data.Status.ValueString() != "" {
-				break
-			}
+	exclude := make(map[string]struct{})
+	if state != nil {
+		for _, id := range r.stateRuleIDs(ctx, state) {
+			exclude[id] = struct{}{}
 		}
-		time.Sleep(5 * time.Second)
 	}
 
-	if data.Status.ValueString() == "" {
-		resp.Diagnostics.AddError(
-			"Port Forwarding Rule Creation Verification Failed",
-			"The port forwarding rule was not found in the list after creation.",
+	collisions := helpers.FindPortForwardCollisions(
+		listResp.Data,
+		helpers.PortForwardProtocols(plan.Protocol.ValueString()),
+		int(plan.PublicPort.ValueInt64()),
+		int(plan.PublicPortEnd.ValueInt64()),
+		exclude,
+	)
+	if len(collisions) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("public_port"),
+			"Port Forward Collision",
+			fmt.Sprintf("Public ports %d-%d (%s) are already forwarded on network %s:\n  - %s",
+				plan.PublicPort.ValueInt64(), plan.PublicPortEnd.ValueInt64(), plan.Protocol.ValueString(),
+				plan.NetworkID.ValueString(), strings.Join(describePortForwards(collisions), "\n  - ")),
 		)
+	}
+}
+
+func (r *portForwardingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.PortForwardingRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.createForwards(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -216,40 +341,80 @@ func (r *portForwardingRuleResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	var foundRule *models.PortForwardingRuleResourceModel
+	byID := make(map[string]responses.PortForwardRule, len(listResp.Data))
 	for _, rule := range listResp.Data {
-		if rule.ID == data.ID.ValueString() {
-			foundRule = &models.PortForwardingRuleResourceModel{
-				ID:          types.StringValue(rule.ID),
-				ZoneID:      data.ZoneID,
-				NetworkID:   data.NetworkID,
-				PublicIPID:  data.PublicIPID,
-				Protocol:    types.StringValue(rule.Protocol),
-				PublicPort:  types.Int64Value(int64(rule.PublicPort)),
-				PrivatePort: types.Int64Value(int64(rule.PrivatePort)),
-				InstanceID:  data.InstanceID,
-				PrivateIP:   types.StringValue(rule.PrivateIP),
-				Status:      types.StringValue(rule.Status),
-				CreatedAt:   types.StringValue(fmt.Sprintf("%d", rule.CreatedAt)),
-			}
-			break
+		byID[rule.ID] = rule
+	}
+
+	legacy := data.RuleIDs.IsNull()
+	var found []responses.PortForwardRule
+	for _, id := range r.stateRuleIDs(ctx, &data) {
+		if rule, ok := byID[id]; ok {
+			found = append(found, rule)
 		}
 	}
 
-	if foundRule == nil {
+	if len(found) == 0 {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	data = *foundRule
+	first := found[0]
+	if legacy {
+		// State written before ranges were supported tracks a single forward.
+		data.Protocol = types.StringValue(first.Protocol)
+		data.PublicPort = types.Int64Value(int64(first.PublicPort))
+		data.PublicPortEnd = data.PublicPort
+		data.PrivatePort = types.Int64Value(int64(first.PrivatePort))
+		data.PrivatePortEnd = data.PrivatePort
+	}
+	data.ID = types.StringValue(first.ID)
+	data.PrivateIP = types.StringValue(first.PrivateIP)
+	data.Status = types.StringValue(first.Status)
+	data.CreatedAt = types.StringValue(fmt.Sprintf("%d", first.CreatedAt))
+
+	ruleIDs, diags := types.ListValueFrom(ctx, types.StringType, helpers.PortForwardRuleIDs(found))
+	resp.Diagnostics.Append(diags...)
+	data.RuleIDs = ruleIDs
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *portForwardingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Port forwarding rule resources do not support updates. To change a port forwarding rule, delete and recreate it.",
-	)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan, state models.PortForwardingRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.RuleIDs.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	// A public port can only be forwarded once, so the old forwards have to
+	// go before the new ones can be created.
+	oldRuleIDs := r.stateRuleIDs(ctx, &state)
+	log.Printf("Replacing %d port forwards of rule %s...", len(oldRuleIDs), state.ID.ValueString())
+	if err := helpers.DeletePortForwards(r.client, state.ZoneID.ValueString(), state.NetworkID.ValueString(), oldRuleIDs); err != nil {
+		resp.Diagnostics.AddError("Port Forwarding Rule Update Failed", err.Error())
+		return
+	}
+
+	r.createForwards(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// The old forwards are gone; remove the resource so the next apply
+		// recreates it instead of assuming the forwards exist.
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	log.Printf("✓ Port forwarding rule updated successfully")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *portForwardingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -262,55 +427,88 @@ func (r *portForwardingRuleResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	// First get port forward list
-	_, err := r.client.ListPortForwards(
-		data.ZoneID.ValueString(),
-		data.NetworkID.ValueString(),
-	)
-	if err != nil {
+	if err := helpers.DeletePortForwards(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), r.stateRuleIDs(ctx, &data)); err != nil {
 		resp.Diagnostics.AddError(
-			"Failed to List Port Forwards",
-			fmt.Sprintf("Failed to list port forwards before deletion. Error: %s", err),
+			"Port Forwarding Rule Deletion Failed",
+			fmt.Sprintf("Failed to delete port forwarding rule '%s'. Error: %s", data.ID.ValueString(), err),
 		)
-		return
 	}
+}
 
-	_, err = r.client.DeletePortForward(
-		data.ZoneID.ValueString(),
-		data.ID.ValueString(),
-	)
+// createForwards creates the API forwards for data and fills in the computed
+// attributes.
+func (r *portForwardingRuleResource) createForwards(ctx context.Context, data *models.PortForwardingRuleResourceModel, diags *diag.Diagnostics) {
+	zoneID := data.ZoneID.ValueString()
+	networkID := data.NetworkID.ValueString()
+
+	if data.PrivateIP.IsNull() || data.PrivateIP.IsUnknown() || data.PrivateIP.ValueString() == "" {
+		privateIP, err := helpers.ResolveInstancePrivateIP(r.client, zoneID, networkID, data.InstanceID.ValueString())
+		if err != nil {
+			diags.AddError("Private IP Lookup Failed", err.Error())
+			return
+		}
+		data.PrivateIP = types.StringValue(privateIP)
+	}
+
+	specs := portForwardSpecs(data)
+	log.Printf("Creating %d port forwards to %s...", len(specs), data.PrivateIP.ValueString())
+	created, err := helpers.CreatePortForwards(r.client, zoneID, networkID, data.PrivateIP.ValueString(), specs)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Port Forwarding Rule Deletion Failed",
-			fmt.Sprintf("Failed to delete port forwarding rule '%s'. Error: %s", data.ID.ValueString(), err),
+		diags.AddError(
+			"Port Forwarding Rule Creation Failed",
+			fmt.Sprintf("Failed to create port forwarding rule. Error: %s", err),
 		)
 		return
 	}
+	log.Printf("✓ Port forwards created successfully")
 
-	// Poll to ensure deletion happened, with 5 second backoff for up to 600 seconds
-	for i := 0; i < 120; i++ {
-		listResp, err := r.client.ListPortForwards(
-			data.ZoneID.ValueString(),
-			data.NetworkID.ValueString(),
-		)
-		if err == nil {
-			found := false
-			for _, rule := range listResp.Data {
-				if rule.ID == data.ID.ValueString() {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return // successfully deleted
-			}
+	first := created[0]
+	data.ID = types.StringValue(first.ID)
+	data.Status = types.StringValue(first.Status)
+	data.CreatedAt = types.StringValue(fmt.Sprintf("%d", first.CreatedAt))
+
+	ruleIDs, d := types.ListValueFrom(ctx, types.StringType, helpers.PortForwardRuleIDs(created))
+	diags.Append(d...)
+	data.RuleIDs = ruleIDs
+}
+
+// stateRuleIDs returns the forwards tracked in state. State written before
+// ranges were supported only has the single forward's ID.
+func (r *portForwardingRuleResource) stateRuleIDs(ctx context.Context, data *models.PortForwardingRuleResourceModel) []string {
+	if data.RuleIDs.IsNull() || data.RuleIDs.IsUnknown() {
+		if data.ID.IsNull() || data.ID.IsUnknown() {
+			return nil
 		}
-		time.Sleep(5 * time.Second)
+		return []string{data.ID.ValueString()}
 	}
+	var ids []string
+	data.RuleIDs.ElementsAs(ctx, &ids, false)
+	return ids
+}
 
-	// If here, deletion not verified
-	resp.Diagnostics.AddError(
-		"Port Forwarding Rule Deletion Verification Failed",
-		"The port forwarding rule was still found in the list after deletion.",
+func portForwardSpecs(data *models.PortForwardingRuleResourceModel) []helpers.PortForwardSpec {
+	return helpers.ExpandPortForwards(
+		data.Protocol.ValueString(),
+		int(data.PublicPort.ValueInt64()),
+		int(data.PublicPortEnd.ValueInt64()),
+		int(data.PrivatePort.ValueInt64()),
 	)
 }
+
+func portForwardMappingChanged(plan, state *models.PortForwardingRuleResourceModel) bool {
+	return !plan.Protocol.Equal(state.Protocol) ||
+		!plan.PublicPort.Equal(state.PublicPort) ||
+		!plan.PublicPortEnd.Equal(state.PublicPortEnd) ||
+		!plan.PrivatePort.Equal(state.PrivatePort) ||
+		!plan.PrivatePortEnd.Equal(state.PrivatePortEnd) ||
+		!plan.InstanceID.Equal(state.InstanceID) ||
+		!plan.PrivateIP.Equal(state.PrivateIP)
+}
+
+func describePortForwards(rules []responses.PortForwardRule) []string {
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		descriptions = append(descriptions, fmt.Sprintf("%s %d -> %s:%d (%s)", rule.Protocol, rule.PublicPort, rule.PrivateIP, rule.PrivatePort, rule.ID))
+	}
+	return descriptions
+}
@@ -60,11 +60,17 @@ func (r *publicIPResource) Schema(ctx context.Context, req resource.SchemaReques
 			"instance_id": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The ID of instance to associate with the public IP for Static NAT.",
+				DeprecationMessage: "Manage static NAT with the virakcloud_public_ip_static_nat resource instead, " +
+					"and allocate the address with virakcloud_public_ip_association.",
 			},
 			"ip_address": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The public IP address.",
 			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the public IP.",
+			},
 		},
 	}
 }
@@ -309,6 +315,10 @@ func (r *publicIPResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Update the state with the new instance_id
+	plan.Status = state.Status
+	if plan.Status.IsNull() || plan.Status.IsUnknown() {
+		plan.Status = types.StringValue("active")
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/virak-cloud/cli/pkg/http"
 	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
 )
 
 // publicIPAssociationResource manages association of a public IP to a private network.
 var _ resource.Resource = &publicIPAssociationResource{}
+var _ resource.ResourceWithImportState = &publicIPAssociationResource{}
 
 func NewPublicIPAssociationResource(mutex *sync.Mutex) resource.Resource {
 	return &publicIPAssociationResource{mutex: mutex}
@@ -30,13 +37,46 @@ func (r *publicIPAssociationResource) Metadata(ctx context.Context, req resource
 }
 
 func (r *publicIPAssociationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	// Associates a static IPv4 address to a private network.
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Associates a static IPv4 address to a private network.",
+		MarkdownDescription: "Allocates a static IPv4 address on a private network. " +
+			"Map it to an instance with `virakcloud_public_ip_static_nat`; moving the mapping between instances leaves the address allocated. " +
+			"Import using `<zone_id>/<network_id>/<public_ip_id>`.",
 		Attributes: map[string]schema.Attribute{
-			"id":         schema.StringAttribute{Computed: true, MarkdownDescription: "The ID of the public IP association."},
-			"network_id": schema.StringAttribute{Required: true, MarkdownDescription: "The ID of the network to associate with public IP."},
-			"ip_address": schema.StringAttribute{Computed: true, MarkdownDescription: "The assigned public IP address."},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the public IP.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the network is located.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the network to associate the public IP with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The assigned public IP address.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_source_nat": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the address is the network's source NAT address.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -57,71 +97,26 @@ func (r *publicIPAssociationResource) Create(ctx context.Context, req resource.C
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	var data models.PublicIPResourceModel
+	var data models.PublicIPAssociationResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	networkID := data.NetworkID.ValueString()
-	if networkID == "" {
-		resp.Diagnostics.AddError("Invalid Configuration", "network_id must be provided")
-		return
-	}
-
 	log.Printf("=== Creating Public IP Association ===")
-	log.Printf("Network ID: %s", networkID)
+	log.Printf("Zone ID: %s", data.ZoneID.ValueString())
+	log.Printf("Network ID: %s", data.NetworkID.ValueString())
 
-	// Use the existing client method to associate a public IP with a network.
-	// Passing empty zone string for now; integrator should provide the appropriate zone if required.
-	associateResp, err := r.client.AssociateNetworkPublicIp("", networkID)
+	publicIP, err := helpers.AssociatePublicIP(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString())
 	if err != nil {
 		log.Printf("Public IP association failed: %v", err)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to associate public IP, got error: %s", err))
+		resp.Diagnostics.AddError("Public IP Association Failed", err.Error())
 		return
 	}
 
-	// Best-effort extraction of returned values. Integrator: adapt to actual response structure.
-	assocID := ""
-	ipAddr := ""
-
-	// Check API action success if present
-	if associateResp != nil {
-		if !associateResp.Data.Success {
-			resp.Diagnostics.AddError("Public IP Association Failed", "API returned failure for public IP association")
-			return
-		}
-	}
-
-	// Try to discover the newly associated public IP by listing network public IPs.
-	publicIPsResp, err := r.client.ListNetworkPublicIps("", networkID)
-	if err == nil && publicIPsResp != nil && len(publicIPsResp.Data) > 0 {
-		latest := publicIPsResp.Data[len(publicIPsResp.Data)-1]
-		assocID = latest.ID
-		ipAddr = latest.IpAddress
-	}
-
-	// If integrator's client uses a pointer/other type, above cast will fail silently.
-	// Fallback: if associateResp has String() helpers, integrator may fill assocID/ipAddr here.
-	// For now, if assocID or ipAddr are empty, attempt a best-effort lookup via a commonly used client method.
-	if assocID == "" || ipAddr == "" {
-		// TODO: If the client provides a List or Show API to query associations by network,
-		// call it here to find the newly-created association and populate assocID/ipAddr.
-		// Example:
-		// listResp, err := r.client.ListNetworkPublicIps(zoneID, networkID)
-		// then find the newest entry and set assocID/ipAddr accordingly.
-	}
-
-	// If still empty, set a placeholder ID to avoid empty state (integrator should replace logic).
-	if assocID == "" {
-		assocID = "unknown-association-id"
-	}
-	if ipAddr == "" {
-		ipAddr = ""
-	}
-
-	data.ID = types.StringValue(assocID)
-	data.IPAddress = types.StringValue(ipAddr)
+	data.ID = types.StringValue(publicIP.ID)
+	data.IPAddress = types.StringValue(publicIP.IpAddress)
+	data.IsSourceNat = types.BoolValue(publicIP.IsSourceNat)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -129,103 +124,82 @@ func (r *publicIPAssociationResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	log.Printf("=== Public IP Association Created: ID=%s, IP=%s ===", assocID, ipAddr)
+	log.Printf("=== Public IP Association Created: ID=%s, IP=%s ===", publicIP.ID, publicIP.IpAddress)
 }
 
 func (r *publicIPAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data models.PublicIPResourceModel
+	var data models.PublicIPAssociationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	assocID := data.ID.ValueString()
-	if assocID == "" {
-		// Nothing to read
-		resp.State.RemoveResource(ctx)
-		return
-	}
-
-	log.Printf("=== Reading Public IP Association: %s ===", assocID)
+	log.Printf("=== Reading Public IP Association: %s ===", data.ID.ValueString())
 
-	// Refresh association by listing public IPs for the network and finding the matching ID.
-	readResp, err := r.client.ListNetworkPublicIps("", data.NetworkID.ValueString())
+	publicIP, err := helpers.FindPublicIP(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.ID.ValueString())
 	if err != nil {
-		// Treat not-found or API error as removed
 		log.Printf("Error reading public IP association: %v", err)
 		resp.State.RemoveResource(ctx)
 		return
 	}
-
-	// Populate state from the list response by locating the matching association ID.
-	if readResp != nil {
-		var foundIP *struct {
-			ID        string
-			IpAddress string
-		}
-		for _, publicIP := range readResp.Data {
-			if publicIP.ID == assocID {
-				// capture minimal fields we care about
-				foundIP = &struct {
-					ID        string
-					IpAddress string
-				}{
-					ID:        publicIP.ID,
-					IpAddress: publicIP.IpAddress,
-				}
-				break
-			}
-		}
-
-		if foundIP == nil {
-			// association not found anymore
-			resp.State.RemoveResource(ctx)
-			return
-		}
-
-		data.IPAddress = types.StringValue(foundIP.IpAddress)
-		// keep network_id as-is (from config/plan)
+	if publicIP == nil {
+		log.Printf("Public IP %s no longer exists, removing from state", data.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
+	data.IPAddress = types.StringValue(publicIP.IpAddress)
+	data.IsSourceNat = types.BoolValue(publicIP.IsSourceNat)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		log.Printf("Error setting state after read: %v", resp.Diagnostics)
-		return
-	}
 }
 
 func (r *publicIPAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No-op for Phase 4.1
+	// Every configurable attribute requires replacement.
+	var plan models.PublicIPAssociationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *publicIPAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	var data models.PublicIPResourceModel
+	var data models.PublicIPAssociationResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	assocID := data.ID.ValueString()
-	if assocID == "" {
-		// Nothing to do
+	log.Printf("=== Deleting Public IP Association: %s ===", data.ID.ValueString())
+
+	disassociateResp, err := r.client.DisassociateNetworkPublicIp(data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disassociate public IP (id=%s), got error: %s", data.ID.ValueString(), err))
+		return
+	}
+	if !disassociateResp.Data.Success {
+		resp.Diagnostics.AddError("Public IP Disassociation Failed", "API returned failure for public IP disassociation")
 		return
 	}
 
-	log.Printf("=== Deleting Public IP Association: %s ===", assocID)
+	log.Printf("=== Public IP Association Deleted: %s ===", data.ID.ValueString())
+}
 
-	// Disassociate the public IP using the client's DisassociateNetworkPublicIp call.
-	// Passing empty zone string for now; integrator should provide the appropriate zone if required.
-	_, err := r.client.DisassociateNetworkPublicIp("", data.NetworkID.ValueString(), assocID)
-	if err != nil {
-		// If API reports not-found, treat as success. Otherwise, surface error.
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disassociate public IP (id=%s), got error: %s", assocID, err))
+func (r *publicIPAssociationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format '<zone_id>/<network_id>/<public_ip_id>', got: %s", req.ID),
+		)
 		return
 	}
 
-	// Remove resource from state
-	resp.State.RemoveResource(ctx)
-	log.Printf("=== Public IP Association Deleted: %s ===", assocID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
 }
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+var _ resource.Resource = &publicIPStaticNatResource{}
+var _ resource.ResourceWithImportState = &publicIPStaticNatResource{}
+
+func NewPublicIPStaticNatResource(mutex *sync.Mutex) resource.Resource {
+	return &publicIPStaticNatResource{mutex: mutex}
+}
+
+type publicIPStaticNatResource struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+func (r *publicIPStaticNatResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_ip_static_nat"
+}
+
+func (r *publicIPStaticNatResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Maps a public IP one-to-one to an instance (static NAT). " +
+			"Changing `instance_id` moves the mapping in place without releasing the public IP. " +
+			"Import using `<zone_id>/<network_id>/<public_ip_id>/<instance_id>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Static NAT ID (the public IP ID).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the network is located.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the network the public IP is associated with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_ip_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the public IP, e.g. from `virakcloud_public_ip_association`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the instance that receives the public IP's traffic.",
+			},
+			"ip_address": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public IP address.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *publicIPStaticNatResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to provider developers.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *publicIPStaticNatResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.PublicIPStaticNatResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneID := data.ZoneID.ValueString()
+	networkID := data.NetworkID.ValueString()
+	publicIPID := data.PublicIPID.ValueString()
+
+	publicIP, err := helpers.FindPublicIP(r.client, zoneID, networkID, publicIPID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read public IP %s, got error: %s", publicIPID, err))
+		return
+	}
+	if publicIP == nil {
+		resp.Diagnostics.AddError("Public IP Not Found", fmt.Sprintf("Public IP %s is not associated with network %s.", publicIPID, networkID))
+		return
+	}
+	if publicIP.StaticNatEnable {
+		resp.Diagnostics.AddError(
+			"Static NAT Already Enabled",
+			fmt.Sprintf("Public IP %s (%s) already has static NAT enabled. Import the existing mapping or disable it first.", publicIP.IpAddress, publicIPID),
+		)
+		return
+	}
+
+	log.Printf("Enabling static NAT from %s to instance %s...", publicIP.IpAddress, data.InstanceID.ValueString())
+	if err := helpers.EnableStaticNat(r.client, zoneID, networkID, publicIPID, data.InstanceID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Static NAT Enable Failed", err.Error())
+		return
+	}
+	log.Printf("✓ Static NAT enabled successfully")
+
+	data.ID = data.PublicIPID
+	data.IPAddress = types.StringValue(publicIP.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *publicIPStaticNatResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data models.PublicIPStaticNatResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	publicIP, err := helpers.FindPublicIP(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.PublicIPID.ValueString())
+	if err != nil {
+		log.Printf("Error reading public IP: %v", err)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if publicIP == nil || !publicIP.StaticNatEnable {
+		log.Printf("Static NAT on public IP %s no longer exists, removing from state", data.PublicIPID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Report a mapping moved to another instance outside Terraform as drift.
+	// An empty list means the API did not report the target, so state is kept.
+	if len(publicIP.StaticNat) > 0 && !slices.Contains(publicIP.StaticNat, data.InstanceID.ValueString()) {
+		log.Printf("Static NAT on public IP %s now targets %v instead of instance %s", data.PublicIPID.ValueString(), publicIP.StaticNat, data.InstanceID.ValueString())
+		data.InstanceID = types.StringValue(publicIP.StaticNat[0])
+	}
+
+	data.ID = data.PublicIPID
+	data.IPAddress = types.StringValue(publicIP.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *publicIPStaticNatResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan models.PublicIPStaticNatResourceModel
+	var state models.PublicIPStaticNatResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.InstanceID.Equal(state.InstanceID) {
+		zoneID := plan.ZoneID.ValueString()
+		networkID := plan.NetworkID.ValueString()
+		publicIPID := plan.PublicIPID.ValueString()
+
+		log.Printf("Moving static NAT on %s from instance %s to %s...", publicIPID, state.InstanceID.ValueString(), plan.InstanceID.ValueString())
+		if err := helpers.DisableStaticNat(r.client, zoneID, networkID, publicIPID); err != nil {
+			resp.Diagnostics.AddError("Static NAT Disable Failed", err.Error())
+			return
+		}
+		if err := helpers.EnableStaticNat(r.client, zoneID, networkID, publicIPID, plan.InstanceID.ValueString()); err != nil {
+			// The old mapping is gone; remove the resource so the next apply
+			// recreates the mapping instead of assuming it exists.
+			resp.Diagnostics.AddError("Static NAT Enable Failed", err.Error())
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		log.Printf("✓ Static NAT moved successfully")
+	}
+
+	plan.ID = plan.PublicIPID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *publicIPStaticNatResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.PublicIPStaticNatResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	log.Printf("Disabling static NAT on public IP %s...", data.PublicIPID.ValueString())
+	if err := helpers.DisableStaticNat(r.client, data.ZoneID.ValueString(), data.NetworkID.ValueString(), data.PublicIPID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Static NAT Disable Failed", err.Error())
+		return
+	}
+	log.Printf("✓ Static NAT disabled successfully")
+}
+
+func (r *publicIPStaticNatResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format '<zone_id>/<network_id>/<public_ip_id>/<instance_id>', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("public_ip_id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_id"), parts[3])...)
+}
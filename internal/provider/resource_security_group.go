@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the resource interfaces.
+var _ resource.Resource = &securityGroupResource{}
+var _ resource.ResourceWithModifyPlan = &securityGroupResource{}
+var _ resource.ResourceWithValidateConfig = &securityGroupResource{}
+
+// NewSecurityGroupResource returns a provider-side security group. The Virak
+// Cloud API has no security group concept, so the group only lives in
+// Terraform state and is applied to networks by virakcloud_security_group_binding.
+func NewSecurityGroupResource() resource.Resource {
+	return &securityGroupResource{}
+}
+
+type securityGroupResource struct{}
+
+func (r *securityGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_group"
+}
+
+func (r *securityGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Defines a named, versioned set of firewall rules that can be applied to many networks with `virakcloud_security_group_binding`, " +
+			"by setting the binding's `security_group` to this group's `spec`. " +
+			"Security groups are a provider-side concept: nothing is created in Virak Cloud until the group is bound to a network.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Security group ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the security group, e.g. `web` or `ssh-from-bastion`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A description of the security group.",
+			},
+			"rules": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The firewall rules in the group. `public_ip_id` is network-specific and cannot be used here.",
+				NestedObject:        firewallRuleNestedObject(),
+			},
+			"version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Starts at 1 and increases each time the rules change.",
+			},
+			"spec": schema.ObjectAttribute{
+				Computed:            true,
+				AttributeTypes:      helpers.GetSecurityGroupSpecObjectType().AttrTypes,
+				MarkdownDescription: "The group's `id`, `version` and `rules` as one value. Set a binding's `security_group` to this.",
+			},
+		},
+	}
+}
+
+func (r *securityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data models.SecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := randomResourceID("sg")
+	if err != nil {
+		resp.Diagnostics.AddError("Security Group Creation Failed", fmt.Sprintf("Unable to generate security group ID, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.Version = types.Int64Value(1)
+	data.Spec = securityGroupSpec(&data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *securityGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Security groups exist only in state; there is nothing to refresh.
+}
+
+func (r *securityGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan models.SecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Spec = securityGroupSpec(&plan, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *securityGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Rules applied to networks are owned and removed by their bindings.
+}
+
+func (r *securityGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.SecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateFirewallRuleSet(ctx, data.Rules, path.Root("rules"), &resp.Diagnostics)
+
+	if data.Rules.IsNull() || data.Rules.IsUnknown() {
+		return
+	}
+	for _, elem := range data.Rules.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var rule models.FirewallRulesetRuleModel
+		resp.Diagnostics.Append(obj.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !rule.PublicIPID.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rules").AtSetValue(obj).AtName("public_ip_id"),
+				"Invalid Configuration",
+				"public_ip_id refers to a single network's public IP and cannot be used in a security group.",
+			)
+		}
+	}
+}
+
+// ModifyPlan plans the next version and spec whenever the rules change, so
+// bindings and outputs can see them before apply.
+func (r *securityGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan models.SecurityGroupResourceModel
+	var state models.SecurityGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Rules.Equal(state.Rules) {
+		plan.Version = state.Version
+	} else if plan.Rules.IsUnknown() {
+		plan.Version = types.Int64Unknown()
+	} else {
+		plan.Version = types.Int64Value(state.Version.ValueInt64() + 1)
+	}
+
+	plan.Spec = securityGroupSpec(&plan, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// securityGroupSpec builds the spec attribute from a group's ID, version and
+// rules. It is unknown while any of them is.
+func securityGroupSpec(data *models.SecurityGroupResourceModel, diags *diag.Diagnostics) types.Object {
+	objectType := helpers.GetSecurityGroupSpecObjectType()
+	if data.ID.IsUnknown() || data.Version.IsUnknown() || data.Rules.IsUnknown() {
+		return types.ObjectUnknown(objectType.AttrTypes)
+	}
+	spec, d := types.ObjectValue(objectType.AttrTypes, map[string]attr.Value{
+		"id":      data.ID,
+		"version": data.Version,
+		"rules":   data.Rules,
+	})
+	diags.Append(d...)
+	return spec
+}
+
+// randomResourceID generates an ID for resources that exist only in
+// Terraform state and therefore have no API-assigned ID.
+func randomResourceID(prefix string) (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return prefix + "-" + hex.EncodeToString(id), nil
+}
@@ -0,0 +1,473 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the resource interfaces.
+var _ resource.Resource = &securityGroupBindingResource{}
+var _ resource.ResourceWithModifyPlan = &securityGroupBindingResource{}
+var _ resource.ResourceWithValidateConfig = &securityGroupBindingResource{}
+
+func NewSecurityGroupBindingResource(mutex *sync.Mutex) resource.Resource {
+	return &securityGroupBindingResource{mutex: mutex}
+}
+
+type securityGroupBindingResource struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+func (r *securityGroupBindingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_group_binding"
+}
+
+func (r *securityGroupBindingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies a `virakcloud_security_group` to one or more networks. " +
+			"Set `security_group` to the group's `spec`; a new group version re-converges every bound network. " +
+			"The binding creates one firewall rule per group rule on every network and re-converges them when the group or the network list changes. " +
+			"Only rules created by the binding are ever modified or removed; other rules on the networks are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Security group binding ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the networks reside.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"security_group": schema.ObjectAttribute{
+				Required:            true,
+				AttributeTypes:      helpers.GetSecurityGroupSpecObjectType().AttrTypes,
+				MarkdownDescription: "The security group to apply. Set this to `virakcloud_security_group.<name>.spec`.",
+			},
+			"network_ids": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the networks the security group is applied to.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"rule_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "The IDs of the firewall rules created by this binding, keyed by network ID.",
+			},
+		},
+	}
+}
+
+func (r *securityGroupBindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *securityGroupBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := randomResourceID("sgb")
+	if err != nil {
+		resp.Diagnostics.AddError("Security Group Binding Creation Failed", fmt.Sprintf("Unable to generate binding ID, got error: %s", err))
+		return
+	}
+	data.ID = types.StringValue(id)
+
+	owned := r.converge(ctx, &data, map[string][]string{}, &resp.Diagnostics)
+	data.RuleIDs = createRuleIDsMap(owned, &resp.Diagnostics)
+
+	// Save whatever was created even on failure so a later apply or destroy
+	// can clean it up.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *securityGroupBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := ruleIDsFromMap(ctx, data.RuleIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Drop owned rules that were removed outside Terraform; ModifyPlan then
+	// notices the shortfall and the next apply recreates them.
+	refreshed := make(map[string][]string, len(owned))
+	for networkID, ruleIDs := range owned {
+		existing, err := helpers.ListFirewallRules(r.client, data.ZoneID.ValueString(), networkID)
+		if err != nil {
+			if gone, _ := helpers.NetworkNotFound(r.client, data.ZoneID.ValueString(), networkID); gone {
+				helpers.LogWarning("Network %s no longer exists, dropping its firewall rules from state", networkID)
+				continue
+			}
+			helpers.HandleAPIError(&resp.Diagnostics, "Client Error", fmt.Errorf("unable to list firewall rules on network %s: %w", networkID, err))
+			return
+		}
+		refreshed[networkID] = []string{}
+		present := make(map[string]struct{}, len(existing))
+		for _, rule := range existing {
+			present[rule.ID] = struct{}{}
+		}
+		for _, ruleID := range ruleIDs {
+			if _, ok := present[ruleID]; ok {
+				refreshed[networkID] = append(refreshed[networkID], ruleID)
+			}
+		}
+	}
+
+	data.RuleIDs = createRuleIDsMap(refreshed, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *securityGroupBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan models.SecurityGroupBindingResourceModel
+	var state models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := ruleIDsFromMap(ctx, state.RuleIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	owned := r.converge(ctx, &plan, prior, &resp.Diagnostics)
+	plan.RuleIDs = createRuleIDsMap(owned, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *securityGroupBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := ruleIDsFromMap(ctx, data.RuleIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, networkID := range sortedKeys(owned) {
+		r.removeOwnedRules(data.ZoneID.ValueString(), networkID, owned[networkID], &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+func (r *securityGroupBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, ok := securityGroupSpecFromObject(ctx, data.SecurityGroup, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	validateFirewallRuleSet(ctx, spec.Rules, path.Root("security_group").AtName("rules"), &resp.Diagnostics)
+}
+
+// ModifyPlan marks rule_ids unknown whenever the group or the networks
+// change, or when rules owned by the binding have disappeared since the last
+// apply.
+func (r *securityGroupBindingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan models.SecurityGroupBindingResourceModel
+	var state models.SecurityGroupBindingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.SecurityGroup.IsUnknown() || plan.NetworkIDs.IsUnknown() || !plan.SecurityGroup.Equal(state.SecurityGroup) || !plan.NetworkIDs.Equal(state.NetworkIDs) {
+		plan.RuleIDs = types.MapUnknown(types.ListType{ElemType: types.StringType})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	owned := ruleIDsFromMap(ctx, state.RuleIDs, &resp.Diagnostics)
+	spec, ok := securityGroupSpecFromObject(ctx, plan.SecurityGroup, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !ok {
+		return
+	}
+
+	desired := len(spec.Rules.Elements())
+	inSync := len(owned) == len(plan.NetworkIDs.Elements())
+	for _, ruleIDs := range owned {
+		if len(ruleIDs) != desired {
+			inSync = false
+		}
+	}
+
+	if inSync {
+		plan.RuleIDs = state.RuleIDs
+	} else {
+		resp.Diagnostics.AddWarning(
+			"Security Group Binding Drift",
+			"Some firewall rules created by this binding no longer exist and will be recreated.",
+		)
+		plan.RuleIDs = types.MapUnknown(types.ListType{ElemType: types.StringType})
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// converge applies the bound rules to every network in data and removes the
+// binding's rules from networks that are no longer bound. Only rules whose IDs
+// are in owned are considered for deletion. It returns the rule IDs owned
+// after the run, including any created before an error occurred.
+func (r *securityGroupBindingResource) converge(ctx context.Context, data *models.SecurityGroupBindingResourceModel, owned map[string][]string, diags *diag.Diagnostics) map[string][]string {
+	zoneID := data.ZoneID.ValueString()
+
+	spec, ok := securityGroupSpecFromObject(ctx, data.SecurityGroup, diags)
+	if !ok {
+		return owned
+	}
+	desired := firewallRulesFromSet(ctx, spec.Rules, diags)
+	if diags.HasError() {
+		return owned
+	}
+	// Rules in a security group never carry IDs of their own.
+	for i := range desired {
+		desired[i].ID = ""
+	}
+
+	var networkIDs []string
+	diags.Append(data.NetworkIDs.ElementsAs(ctx, &networkIDs, false)...)
+	if diags.HasError() {
+		return owned
+	}
+	sort.Strings(networkIDs)
+
+	result := make(map[string][]string, len(networkIDs))
+	for networkID, ruleIDs := range owned {
+		result[networkID] = ruleIDs
+	}
+
+	bound := make(map[string]struct{}, len(networkIDs))
+	for _, networkID := range networkIDs {
+		bound[networkID] = struct{}{}
+
+		existing, err := helpers.ListFirewallRules(r.client, zoneID, networkID)
+		if err != nil {
+			helpers.HandleAPIError(diags, "Client Error", err)
+			return result
+		}
+
+		ownedIDs := make(map[string]struct{}, len(result[networkID]))
+		for _, ruleID := range result[networkID] {
+			ownedIDs[ruleID] = struct{}{}
+		}
+		var current []helpers.FirewallRule
+		for _, rule := range existing {
+			if _, ok := ownedIDs[rule.ID]; ok {
+				current = append(current, rule)
+			}
+		}
+
+		toCreate, toDelete := helpers.DiffFirewallRules(desired, current)
+		helpers.LogInfo("Security group %s (version %d) on network %s: %d rule(s) to create, %d rule(s) to delete", spec.ID.ValueString(), spec.Version.ValueInt64(), networkID, len(toCreate), len(toDelete))
+
+		kept := make([]string, 0, len(current)+len(toCreate))
+		deleted := make(map[string]struct{}, len(toDelete))
+		for _, rule := range toDelete {
+			deleted[rule.ID] = struct{}{}
+		}
+		for _, rule := range current {
+			if _, ok := deleted[rule.ID]; !ok {
+				kept = append(kept, rule.ID)
+			}
+		}
+
+		for _, rule := range toCreate {
+			ruleID, err := helpers.CreateFirewallRule(r.client, zoneID, networkID, rule)
+			if err != nil {
+				result[networkID] = append(kept, ruleIDsOf(toDelete)...)
+				diags.AddError("Firewall Rule Creation Failed", err.Error())
+				return result
+			}
+			kept = append(kept, ruleID)
+			helpers.LogInfo("✓ Created firewall rule %s (%s)", rule, ruleID)
+		}
+
+		result[networkID] = kept
+		for i, rule := range toDelete {
+			if err := helpers.DeleteFirewallRule(r.client, zoneID, networkID, rule); err != nil {
+				result[networkID] = append(kept, ruleIDsOf(toDelete[i:])...)
+				diags.AddError("Firewall Rule Deletion Failed", err.Error())
+				return result
+			}
+			helpers.LogInfo("✓ Deleted firewall rule %s", rule)
+		}
+	}
+
+	for _, networkID := range sortedKeys(owned) {
+		if _, ok := bound[networkID]; ok {
+			continue
+		}
+		remaining := r.removeOwnedRules(zoneID, networkID, owned[networkID], diags)
+		if diags.HasError() {
+			result[networkID] = remaining
+			return result
+		}
+		delete(result, networkID)
+	}
+
+	return result
+}
+
+// removeOwnedRules deletes the given rules from a network, ignoring rules that
+// no longer exist. It returns the IDs that could not be deleted.
+func (r *securityGroupBindingResource) removeOwnedRules(zoneID, networkID string, ruleIDs []string, diags *diag.Diagnostics) []string {
+	existing, err := helpers.ListFirewallRules(r.client, zoneID, networkID)
+	if err != nil {
+		if gone, _ := helpers.NetworkNotFound(r.client, zoneID, networkID); gone {
+			// The network itself is gone, and its rules with it.
+			helpers.LogWarning("Network %s no longer exists, its firewall rules are gone with it", networkID)
+			return nil
+		}
+		helpers.HandleAPIError(diags, "Client Error", fmt.Errorf("unable to list firewall rules on network %s: %w", networkID, err))
+		return ruleIDs
+	}
+
+	byID := make(map[string]helpers.FirewallRule, len(existing))
+	for _, rule := range existing {
+		byID[rule.ID] = rule
+	}
+
+	for i, ruleID := range ruleIDs {
+		rule, ok := byID[ruleID]
+		if !ok {
+			continue
+		}
+		if err := helpers.DeleteFirewallRule(r.client, zoneID, networkID, rule); err != nil {
+			diags.AddError("Firewall Rule Deletion Failed", err.Error())
+			return ruleIDs[i:]
+		}
+		helpers.LogInfo("✓ Deleted firewall rule %s", rule)
+	}
+
+	return nil
+}
+
+// securityGroupSpecFromObject reads a binding's security_group. It returns
+// false without diagnostics while the value is not yet known.
+func securityGroupSpecFromObject(ctx context.Context, obj types.Object, diags *diag.Diagnostics) (models.SecurityGroupSpecModel, bool) {
+	var spec models.SecurityGroupSpecModel
+	if obj.IsNull() || obj.IsUnknown() {
+		return spec, false
+	}
+	diags.Append(obj.As(ctx, &spec, basetypes.ObjectAsOptions{})...)
+	return spec, !diags.HasError()
+}
+
+func ruleIDsOf(rules []helpers.FirewallRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func ruleIDsFromMap(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string][]string {
+	result := map[string][]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return result
+	}
+	diags.Append(m.ElementsAs(ctx, &result, false)...)
+	return result
+}
+
+func createRuleIDsMap(ruleIDs map[string][]string, diags *diag.Diagnostics) types.Map {
+	elemType := types.ListType{ElemType: types.StringType}
+	elements := make(map[string]attr.Value, len(ruleIDs))
+	for networkID, ids := range ruleIDs {
+		values := make([]attr.Value, 0, len(ids))
+		for _, id := range ids {
+			values = append(values, types.StringValue(id))
+		}
+		list, d := types.ListValue(types.StringType, values)
+		diags.Append(d...)
+		elements[networkID] = list
+	}
+
+	result, d := types.MapValue(elemType, elements)
+	diags.Append(d...)
+	return result
+}
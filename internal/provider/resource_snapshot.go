@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -76,6 +75,7 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 			"revert": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Set to true to revert the instance to this snapshot. This will trigger a revert operation on the next apply.",
+				DeprecationMessage:  "Use the virakcloud_snapshot_revert action instead. The revert attribute will be removed in a future release.",
 			},
 		},
 	}
@@ -129,55 +129,31 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Find the new snapshot ID by listing snapshots for the instance
-	var snapshotID string
-	var snapshotStatus string
-	var createdAt string
-
 	// Poll for the snapshot to appear and be ready
-	for i := 0; i < 120; i++ { // Retry for up to 600 seconds
-		instanceResp, err := r.client.ShowInstance(data.ZoneID.ValueString(), data.InstanceID.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance after snapshot creation, got error: %s", err))
-			return
-		}
-
-		// Find the snapshot with matching name
-		for _, snap := range instanceResp.Data.Snapshot {
-			if snap.Name == data.Name.ValueString() {
-				snapshotID = snap.ID
-				snapshotStatus = snap.Status
-				createdAt = fmt.Sprintf("%d", snap.CreatedAt)
-				break
-			}
+	snap, err := helpers.WaitForSnapshotReady(r.client, data.ZoneID.ValueString(), data.InstanceID.ValueString(), data.Name.ValueString(), 120, helpers.DefaultPollInterval)
+	if snap.ID == "" {
+		if err == nil {
+			err = fmt.Errorf("snapshot not found")
 		}
-
-		if snapshotID != "" {
-			// If status is READY, we can proceed
-			if snapshotStatus == "READY" {
-				break
-			}
-			// If status is WAITING, continue polling
-		}
-		time.Sleep(5 * time.Second)
-	}
-
-	if snapshotID == "" {
 		resp.Diagnostics.AddError(
 			"Snapshot Creation Timeout",
-			fmt.Sprintf("Snapshot '%s' was submitted for creation but could not be found in the API after 600 seconds.", data.Name.ValueString()),
+			fmt.Sprintf("Snapshot '%s' was submitted for creation but could not be found in the API after 600 seconds. Error: %s", data.Name.ValueString(), err),
 		)
 		return
 	}
 
-	if snapshotStatus != "READY" {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Snapshot Not Ready",
-			fmt.Sprintf("Snapshot '%s' was created but did not reach 'READY' status within 600 seconds. Current status: %s.", data.Name.ValueString(), snapshotStatus),
+			fmt.Sprintf("Snapshot '%s' was created but did not reach 'READY' status within 600 seconds. Current status: %s.", data.Name.ValueString(), snap.Status),
 		)
 		return
 	}
 
+	snapshotID := snap.ID
+	snapshotStatus := snap.Status
+	createdAt := fmt.Sprintf("%d", snap.CreatedAt)
+
 	data.ID = types.StringValue(snapshotID)
 	data.Status = types.StringValue(snapshotStatus)
 	data.CreatedAt = types.StringValue(createdAt)
@@ -236,39 +212,9 @@ func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateReques
 
 	// Handle revert operation
 	if !plan.Revert.IsNull() && plan.Revert.ValueBool() && (!state.Revert.ValueBool() || state.Revert.IsNull()) {
-		revertResp, err := r.client.RevertInstanceSnapshot(
-			plan.ZoneID.ValueString(),
-			plan.InstanceID.ValueString(),
-			plan.ID.ValueString(),
-		)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Snapshot Revert Failed",
-				fmt.Sprintf("Failed to revert instance %s to snapshot %s in zone %s. Error: %s", plan.InstanceID.ValueString(), plan.ID.ValueString(), plan.ZoneID.ValueString(), err),
-			)
-			return
-		}
-
-		if !revertResp.Data.Success {
-			resp.Diagnostics.AddError(
-				"Snapshot Revert Failed",
-				fmt.Sprintf("API returned failure for snapshot revert '%s'", plan.ID.ValueString()),
-			)
+		if err := helpers.RevertToSnapshot(r.client, plan.ZoneID.ValueString(), plan.InstanceID.ValueString(), plan.ID.ValueString(), &resp.Diagnostics); err != nil {
 			return
 		}
-
-		for i := 0; i < 120; i++ {
-			instanceResp, err := r.client.ShowInstance(plan.ZoneID.ValueString(), plan.InstanceID.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read instance after revert, got error: %s", err))
-				return
-			}
-
-			if instanceResp.Data.Status == helpers.InstanceStatusUP || instanceResp.Data.Status == helpers.InstanceStatusRunning {
-				break
-			}
-			time.Sleep(5 * time.Second)
-		}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
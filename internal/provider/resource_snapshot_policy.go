@@ -0,0 +1,468 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/virak-cloud/cli/pkg/http"
+	"github.com/virak-cloud/terraform-provider-virak/internal/models"
+	"github.com/virak-cloud/terraform-provider-virak/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the resource interfaces.
+var _ resource.Resource = &snapshotPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &snapshotPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &snapshotPolicyResource{}
+
+func NewSnapshotPolicyResource(mutex *sync.Mutex) resource.Resource {
+	return &snapshotPolicyResource{mutex: mutex}
+}
+
+type snapshotPolicyResource struct {
+	client *http.Client
+	mutex  *sync.Mutex
+}
+
+func (r *snapshotPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_policy"
+}
+
+func (r *snapshotPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	intervals := []string{helpers.SnapshotIntervalHourly, helpers.SnapshotIntervalDaily, helpers.SnapshotIntervalWeekly, helpers.SnapshotIntervalMonthly}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a snapshot schedule and retention policy for a Virak Cloud instance. " +
+			"The API has no native snapshot scheduling, so the policy is reconciled on every apply: " +
+			"overdue snapshots are created and snapshots beyond the retention count are deleted. " +
+			"Run `terraform apply` periodically (for example from CI) to keep the schedule.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Snapshot policy ID (the instance ID).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the zone where the instance resides.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the instance to snapshot.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("tfpolicy"),
+				MarkdownDescription: "Prefix of the snapshots managed by this policy. Snapshots are named `<prefix>-<interval>-<unix time>`; snapshots with other names are never touched. Defaults to `tfpolicy`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 32),
+				},
+			},
+			"schedule": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "Intervals at which snapshots are taken. Valid values: 'hourly', 'daily', 'weekly', 'monthly'.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(intervals...)),
+				},
+			},
+			"retention": schema.SingleNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Number of snapshots to keep per interval. Every scheduled interval needs a count of at least 1; snapshots of intervals with no count are pruned.",
+				Attributes: map[string]schema.Attribute{
+					"hourly": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Hourly snapshots to keep.",
+						Validators:          []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"daily": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Daily snapshots to keep.",
+						Validators:          []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"weekly": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Weekly snapshots to keep.",
+						Validators:          []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"monthly": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Monthly snapshots to keep.",
+						Validators:          []validator.Int64{int64validator.AtLeast(0)},
+					},
+				},
+			},
+			"prune_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete the snapshots managed by this policy when the policy is destroyed. Defaults to `false`.",
+			},
+			"snapshots": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Snapshots currently managed by this policy, newest first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the snapshot.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the snapshot.",
+						},
+						"interval": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The schedule interval the snapshot was taken for.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the snapshot.",
+						},
+						"taken_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the snapshot was taken (RFC 3339).",
+						},
+					},
+				},
+			},
+			"last_reconciled_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the policy was last reconciled (RFC 3339).",
+			},
+			"last_report": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Actions taken during the last reconciliation.",
+			},
+		},
+	}
+}
+
+func (r *snapshotPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*http.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *snapshotPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.InstanceID
+	r.reconcile(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *snapshotPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceResp, err := r.client.ShowInstance(data.ZoneID.ValueString(), data.InstanceID.ValueString())
+	if err != nil {
+		if helpers.IsNotFoundError(err) {
+			// The instance is gone, and its snapshots with it.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		helpers.HandleAPIError(&resp.Diagnostics, "Client Error", fmt.Errorf("unable to read instance for snapshot policy: %w", err))
+		return
+	}
+
+	snapshots := helpers.ParsePolicySnapshots(data.NamePrefix.ValueString(), instanceResp.Data.Snapshot)
+	data.Snapshots = helpers.CreatePolicySnapshotsList(snapshots, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *snapshotPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var plan models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.InstanceID
+	r.reconcile(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *snapshotPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var data models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceResp, err := r.client.ShowInstance(data.ZoneID.ValueString(), data.InstanceID.ValueString())
+	if err != nil {
+		// Nothing left to prune if the instance no longer exists.
+		return
+	}
+	snapshots := helpers.ParsePolicySnapshots(data.NamePrefix.ValueString(), instanceResp.Data.Snapshot)
+	if len(snapshots) == 0 {
+		return
+	}
+
+	if !data.PruneOnDestroy.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Snapshots Retained",
+			fmt.Sprintf("Snapshot policy for instance %s was removed but its %d snapshot(s) were kept. Set prune_on_destroy = true to delete them with the policy.", data.InstanceID.ValueString(), len(snapshots)),
+		)
+		return
+	}
+
+	for _, snap := range snapshots {
+		if err := r.deleteSnapshot(data.ZoneID.ValueString(), data.InstanceID.ValueString(), snap); err != nil {
+			resp.Diagnostics.AddError("Snapshot Deletion Failed", err.Error())
+			return
+		}
+	}
+}
+
+func (r *snapshotPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Schedule.IsUnknown() || data.Retention == nil {
+		return
+	}
+
+	schedule, retention := r.policy(ctx, &data, &resp.Diagnostics)
+	for _, interval := range schedule {
+		if retention[interval] < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retention").AtName(interval),
+				"Missing Retention Count",
+				fmt.Sprintf("The '%s' interval is scheduled, so retention.%s must be at least 1.", interval, interval),
+			)
+		}
+	}
+}
+
+// ModifyPlan forces an update when snapshots are overdue or have expired so
+// that every apply reconciles the policy, even without configuration changes.
+func (r *snapshotPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan models.SnapshotPolicyResourceModel
+	var state models.SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Schedule.IsUnknown() || plan.Retention == nil || state.Snapshots.IsNull() || state.Snapshots.IsUnknown() {
+		return
+	}
+
+	var stateSnapshots []models.SnapshotPolicySnapshotModel
+	resp.Diagnostics.Append(state.Snapshots.ElementsAs(ctx, &stateSnapshots, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots := make([]helpers.PolicySnapshot, 0, len(stateSnapshots))
+	for _, snap := range stateSnapshots {
+		takenAt, err := time.Parse(time.RFC3339, snap.TakenAt.ValueString())
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, helpers.PolicySnapshot{
+			ID:       snap.ID.ValueString(),
+			Name:     snap.Name.ValueString(),
+			Interval: snap.Interval.ValueString(),
+			Status:   snap.Status.ValueString(),
+			TakenAt:  takenAt,
+		})
+	}
+
+	schedule, retention := r.policy(ctx, &plan, &resp.Diagnostics)
+	actions := helpers.PlanSnapshotPolicy(snapshots, schedule, retention, time.Now().UTC())
+	if actions.Empty() {
+		return
+	}
+
+	plan.Snapshots = types.ListUnknown(helpers.GetPolicySnapshotObjectType())
+	plan.LastReconciledAt = types.StringUnknown()
+	plan.LastReport = types.ListUnknown(types.StringType)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+	resp.Diagnostics.AddWarning(
+		"Snapshot Policy Reconciliation Pending",
+		fmt.Sprintf("Snapshot policy for instance %s will:\n  - %s", plan.InstanceID.ValueString(), strings.Join(actions.Describe(), "\n  - ")),
+	)
+}
+
+// policy returns the scheduled intervals and the retention count per interval.
+func (r *snapshotPolicyResource) policy(ctx context.Context, data *models.SnapshotPolicyResourceModel, diags *diag.Diagnostics) ([]string, map[string]int64) {
+	var schedule []string
+	if !data.Schedule.IsNull() && !data.Schedule.IsUnknown() {
+		diags.Append(data.Schedule.ElementsAs(ctx, &schedule, false)...)
+	}
+
+	retention := make(map[string]int64)
+	if data.Retention != nil {
+		retention[helpers.SnapshotIntervalHourly] = data.Retention.Hourly.ValueInt64()
+		retention[helpers.SnapshotIntervalDaily] = data.Retention.Daily.ValueInt64()
+		retention[helpers.SnapshotIntervalWeekly] = data.Retention.Weekly.ValueInt64()
+		retention[helpers.SnapshotIntervalMonthly] = data.Retention.Monthly.ValueInt64()
+	}
+	return schedule, retention
+}
+
+// reconcile creates overdue snapshots, prunes expired ones and records what it
+// did in the model.
+func (r *snapshotPolicyResource) reconcile(ctx context.Context, data *models.SnapshotPolicyResourceModel, diags *diag.Diagnostics) {
+	zoneID := data.ZoneID.ValueString()
+	instanceID := data.InstanceID.ValueString()
+	prefix := data.NamePrefix.ValueString()
+
+	instanceResp, err := r.client.ShowInstance(zoneID, instanceID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read instance %s for snapshot policy, got error: %s", instanceID, err))
+		return
+	}
+
+	schedule, retention := r.policy(ctx, data, diags)
+	if diags.HasError() {
+		return
+	}
+
+	now := time.Now().UTC()
+	actions := helpers.PlanSnapshotPolicy(helpers.ParsePolicySnapshots(prefix, instanceResp.Data.Snapshot), schedule, retention, now)
+	report := make([]string, 0)
+
+	for _, interval := range actions.Create {
+		name := helpers.SnapshotPolicyName(prefix, interval, now)
+		createResp, err := r.client.CreateInstanceSnapshot(zoneID, instanceID, name)
+		if err != nil {
+			diags.AddError("Snapshot Creation Failed", fmt.Sprintf("Failed to create %s snapshot '%s' for instance %s. Error: %s", interval, name, instanceID, err))
+			break
+		}
+		if !createResp.Data.Success {
+			diags.AddError("Snapshot Creation Failed", fmt.Sprintf("API returned failure for snapshot creation '%s'", name))
+			break
+		}
+
+		snap, err := helpers.WaitForSnapshotReady(r.client, zoneID, instanceID, name, helpers.MaxInstanceStatusRetries, helpers.DefaultPollInterval)
+		if err != nil {
+			diags.AddError("Snapshot Not Ready", fmt.Sprintf("Snapshot '%s' did not reach '%s' status. Current status: %s. Error: %s", name, helpers.SnapshotStatusReady, snap.Status, err))
+			break
+		}
+		report = append(report, fmt.Sprintf("created %s snapshot '%s' (%s)", interval, name, snap.ID))
+	}
+
+	if !diags.HasError() {
+		for _, snap := range actions.Delete {
+			if err := r.deleteSnapshot(zoneID, instanceID, snap); err != nil {
+				diags.AddError("Snapshot Deletion Failed", err.Error())
+				break
+			}
+			report = append(report, fmt.Sprintf("deleted expired %s snapshot '%s' (%s)", snap.Interval, snap.Name, snap.ID))
+		}
+	}
+
+	if len(report) > 0 {
+		diags.AddWarning("Snapshot Policy Reconciled", fmt.Sprintf("Snapshot policy for instance %s:\n  - %s", instanceID, strings.Join(report, "\n  - ")))
+	} else {
+		report = append(report, "no changes: snapshots are current and within retention")
+	}
+	if diags.HasError() {
+		return
+	}
+
+	instanceResp, err = r.client.ShowInstance(zoneID, instanceID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read instance %s after snapshot policy reconciliation, got error: %s", instanceID, err))
+		return
+	}
+
+	data.Snapshots = helpers.CreatePolicySnapshotsList(helpers.ParsePolicySnapshots(prefix, instanceResp.Data.Snapshot), diags)
+	data.LastReconciledAt = types.StringValue(now.Format(time.RFC3339))
+	reportList, listDiags := types.ListValueFrom(ctx, types.StringType, report)
+	diags.Append(listDiags...)
+	data.LastReport = reportList
+}
+
+func (r *snapshotPolicyResource) deleteSnapshot(zoneID, instanceID string, snap helpers.PolicySnapshot) error {
+	deleteResp, err := r.client.DeleteInstanceSnapshot(zoneID, instanceID, snap.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot '%s' (%s) for instance %s: %w", snap.Name, snap.ID, instanceID, err)
+	}
+	if !deleteResp.Data.Success {
+		return fmt.Errorf("API returned failure for snapshot deletion '%s' (%s)", snap.Name, snap.ID)
+	}
+	return nil
+}